@@ -0,0 +1,95 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/sunku5494/go-ast-parser/pkg/apicheck"
+	"github.com/sunku5494/go-ast-parser/pkg/loader"
+)
+
+// runAPICheck implements the `apicheck` subcommand, which extracts the
+// exported API of the main module and checks it against a baseline.
+func runAPICheck(args []string) int {
+	fs := flag.NewFlagSet("apicheck", flag.ExitOnError)
+	globals := addGlobalFlags(fs)
+	baselineFile := fs.String("baseline", "", "API file to check compatibility against")
+	writeFile := fs.String("write", "", "Write the current API to this file")
+	extractFlags := addExtractionFlags(fs)
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: %s apicheck [-path /path/to/go/project] [-baseline api.txt] [-write api.txt]\n", os.Args[0])
+		fs.PrintDefaults()
+	}
+	globals.parse(args)
+
+	if fs.NArg() > 0 {
+		fs.Usage()
+		return exitUsage
+	}
+	projectPath, err := globals.projectRoot()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return exitLoad
+	}
+
+	ex, err := extractFlags.extraction(projectPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return exitLoad
+	}
+
+	// Only the main module has an API; the vendor directory is not loaded,
+	// and test files are not part of it
+	ex.Load.Tests = false
+	patterns := ex.Load.Patterns
+	if len(patterns) == 0 {
+		patterns = []string{"./..."}
+	}
+	pkgs, err := loader.LoadPackagesWithOptions(projectPath, ex.Load, patterns...)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error loading Go project: %v\n", err)
+		return exitLoad
+	}
+	features := apicheck.Features(pkgs, projectPath)
+
+	if *baselineFile == "" && *writeFile == "" {
+		for _, feature := range features {
+			fmt.Println(feature)
+		}
+		return exitOK
+	}
+
+	if *writeFile != "" {
+		if err := apicheck.WriteFeatures(features, *writeFile); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return exitOutput
+		}
+		globals.progressf("Wrote %d API features to %s", len(features), *writeFile)
+	}
+
+	if *baselineFile == "" {
+		return exitOK
+	}
+
+	baseline, err := apicheck.ReadFeatures(*baselineFile)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return exitLoad
+	}
+
+	result := apicheck.Check(baseline, features)
+	for _, feature := range result.Incompatible {
+		fmt.Printf("-%s\n", feature)
+	}
+	for _, feature := range result.Added {
+		fmt.Printf("+%s\n", feature)
+	}
+
+	if !result.Compatible() {
+		fmt.Fprintf(os.Stderr, "API check failed: %d incompatible changes\n", len(result.Incompatible))
+		return exitFailed
+	}
+	fmt.Fprintf(os.Stderr, "API check passed (%d additions)\n", len(result.Added))
+	return exitOK
+}
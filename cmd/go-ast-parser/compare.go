@@ -0,0 +1,129 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/sunku5494/go-ast-parser/pkg/compare"
+	"github.com/sunku5494/go-ast-parser/pkg/output"
+	"github.com/sunku5494/go-ast-parser/pkg/types"
+)
+
+// runCompare implements the `compare` subcommand, which reports the symbols
+// that changed between two chunk outputs or two git revisions.
+func runCompare(args []string) int {
+	fs := flag.NewFlagSet("compare", flag.ExitOnError)
+	globals := addGlobalFlags(fs)
+	fromRev := fs.String("from", "", "Compare this git revision of the -path module instead of two chunk files")
+	toRev := fs.String("to", "", "Target git revision (with -from; default: the working tree)")
+	jsonOutput := fs.Bool("json", false, "Print the report as JSON")
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: %s compare [flags] OLD.json NEW.json\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "       %s compare [-path /path/to/go/project] -from REV [-to REV]\n", os.Args[0])
+		fs.PrintDefaults()
+	}
+	globals.parse(args)
+
+	var oldChunks, newChunks []types.ChromaDocument
+	var err error
+	switch {
+	case *fromRev != "" && fs.NArg() == 0:
+		var projectPath string
+		if projectPath, err = globals.projectRoot(); err == nil {
+			oldChunks, newChunks, err = chunksForRevisions(projectPath, *fromRev, *toRev)
+		}
+	case *fromRev == "" && fs.NArg() == 2:
+		oldChunks, err = output.ReadChunksFromJSON(fs.Arg(0))
+		if err == nil {
+			newChunks, err = output.ReadChunksFromJSON(fs.Arg(1))
+		}
+	default:
+		fs.Usage()
+		return exitUsage
+	}
+	if isCanceled(err) {
+		fmt.Fprintf(os.Stderr, "Stopped: %v\n", err)
+		return exitCanceled
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return exitLoad
+	}
+
+	report := compare.Compare(oldChunks, newChunks)
+
+	if *jsonOutput {
+		jsonData, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return exitOutput
+		}
+		fmt.Println(string(jsonData))
+		return exitOK
+	}
+
+	printCompareReport(report)
+	return exitOK
+}
+
+// chunksForRevisions extracts the chunks of two revisions of a project; an
+// empty toRev uses the working tree.
+func chunksForRevisions(projectPath, fromRev, toRev string) ([]types.ChromaDocument, []types.ChromaDocument, error) {
+	// Both sides use the working tree's configuration so that differences in
+	// settings do not show up as changes
+	ex, err := loadExtraction(projectPath, "")
+	if err != nil {
+		return nil, nil, err
+	}
+	ctx, cancel := ex.context()
+	defer cancel()
+
+	oldChunks, err := extractChunksAtRevision(ctx, projectPath, fromRev, ex)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var newChunks []types.ChromaDocument
+	if toRev != "" {
+		newChunks, err = extractChunksAtRevision(ctx, projectPath, toRev, ex)
+	} else {
+		newChunks, err = extractChunks(ctx, projectPath, ex)
+	}
+	return oldChunks, newChunks, err
+}
+
+// printCompareReport prints breaking changes first, followed by all other changes.
+func printCompareReport(report compare.Report) {
+	markers := map[string]string{
+		compare.Added:             "+",
+		compare.Removed:           "-",
+		compare.SignatureChanged:  "~",
+		compare.BodyChanged:       "*",
+		compare.EntityTypeChanged: "!",
+	}
+
+	for _, breaking := range []bool{true, false} {
+		if breaking {
+			fmt.Println("Breaking changes:")
+		} else {
+			fmt.Println("Other changes:")
+		}
+		for _, change := range report.Changes {
+			if change.IsBreaking != breaking {
+				continue
+			}
+			fmt.Printf("  %s %-19s %s (%s)\n", markers[change.Kind], change.Kind, change.Symbol, change.EntityType)
+			switch change.Kind {
+			case compare.SignatureChanged:
+				fmt.Printf("      old: %s\n      new: %s\n", change.OldSignature, change.NewSignature)
+			case compare.EntityTypeChanged:
+				fmt.Printf("      old: %s\n      new: %s\n", change.OldEntityType, change.EntityType)
+			}
+		}
+	}
+
+	fmt.Printf("Summary: %d added, %d removed, %d modified (%d breaking)\n",
+		report.Added, report.Removed, report.Modified, report.Breaking)
+}
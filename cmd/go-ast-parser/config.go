@@ -0,0 +1,56 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/sunku5494/go-ast-parser/pkg/config"
+)
+
+// runConfig implements the `config` subcommand. `config validate` checks a
+// configuration file and reports every unknown key.
+func runConfig(args []string) int {
+	fs := flag.NewFlagSet("config", flag.ExitOnError)
+	globals := addGlobalFlags(fs)
+	configFile := fs.String("config", "", "Configuration file to check instead of the discovered one")
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: %s config validate [-path /path/to/go/project] [-config file]\n", os.Args[0])
+		fs.PrintDefaults()
+	}
+	if len(args) == 0 || args[0] != "validate" {
+		fs.Usage()
+		return exitUsage
+	}
+	globals.parse(args[1:])
+
+	path := *configFile
+	if path == "" {
+		projectPath, err := globals.projectRoot()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return exitLoad
+		}
+		if path = config.Discover(projectPath); path == "" {
+			fmt.Fprintf(os.Stderr, "Error: no configuration file found in %s (looked for %v)\n", projectPath, config.FileNames)
+			return exitLoad
+		}
+	}
+
+	_, err := config.Load(path)
+	var unknown *config.UnknownKeysError
+	switch {
+	case errors.As(err, &unknown):
+		for _, key := range unknown.Keys {
+			fmt.Printf("%s: unknown key %q\n", path, key)
+		}
+		return exitFailed
+	case err != nil:
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return exitLoad
+	}
+
+	fmt.Printf("%s: OK\n", path)
+	return exitOK
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/sunku5494/go-ast-parser/pkg/git"
+)
+
+// runDiff implements the `diff` subcommand, which maps the hunks changed
+// between two git revisions to the declarations enclosing them.
+func runDiff(args []string) int {
+	fs := flag.NewFlagSet("diff", flag.ExitOnError)
+	globals := addGlobalFlags(fs)
+	fromRev := fs.String("from", "", "Base git revision")
+	toRev := fs.String("to", "", "Target git revision (default: the working tree)")
+	outputFile := fs.String("output", "", "Write the JSON result to this file instead of stdout")
+	extractFlags := addExtractionFlags(fs)
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: %s diff [-path /path/to/go/project] -from REV [-to REV]\n", os.Args[0])
+		fs.PrintDefaults()
+	}
+	globals.parse(args)
+
+	if *fromRev == "" || fs.NArg() > 0 {
+		fs.Usage()
+		return exitUsage
+	}
+	projectPath, err := globals.projectRoot()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return exitLoad
+	}
+
+	ex, err := extractFlags.extraction(projectPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return exitLoad
+	}
+
+	ctx, cancel := ex.context()
+	diffs, err := git.DiffDeclarationsContext(ctx, projectPath, *fromRev, *toRev, ex.Load, ex.Parse)
+	cancel()
+	if isCanceled(err) {
+		fmt.Fprintf(os.Stderr, "Stopped: %v\n", err)
+		return exitCanceled
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return exitLoad
+	}
+	if diffs == nil {
+		diffs = []git.DeclarationDiff{}
+	}
+
+	jsonData, err := json.MarshalIndent(diffs, "", "  ")
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return exitOutput
+	}
+
+	if *outputFile == "" {
+		fmt.Println(string(jsonData))
+		return exitOK
+	}
+	if err := os.WriteFile(*outputFile, jsonData, 0644); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return exitOutput
+	}
+	return exitOK
+}
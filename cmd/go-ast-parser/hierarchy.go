@@ -0,0 +1,81 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/sunku5494/go-ast-parser/pkg/hierarchy"
+	"github.com/sunku5494/go-ast-parser/pkg/loader"
+)
+
+// runHierarchy implements the `hierarchy` subcommand, which exports the
+// embedding and interface satisfaction graph of the project's types.
+func runHierarchy(args []string) int {
+	fs := flag.NewFlagSet("hierarchy", flag.ExitOnError)
+	globals := addGlobalFlags(fs)
+	format := fs.String("format", "json", "Output format: json or dot")
+	outputFile := fs.String("output", "", "Write the graph to this file instead of stdout")
+	extractFlags := addExtractionFlags(fs)
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: %s hierarchy [-path /path/to/go/project] [-format json|dot] [-output file] [flags]\n", os.Args[0])
+		fs.PrintDefaults()
+	}
+	globals.parse(args)
+
+	if fs.NArg() > 0 {
+		fs.Usage()
+		return exitUsage
+	}
+	if *format != "json" && *format != "dot" {
+		fmt.Fprintf(os.Stderr, "Error: unknown format %q (use json or dot)\n", *format)
+		return exitUsage
+	}
+	projectPath, err := globals.projectRoot()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return exitLoad
+	}
+
+	ex, err := extractFlags.extraction(projectPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return exitLoad
+	}
+
+	ctx, cancel := ex.context()
+	allPkgs, err := loader.LoadGoProjectContext(ctx, projectPath, ex.Load)
+	cancel()
+	if isCanceled(err) {
+		fmt.Fprintf(os.Stderr, "Stopped: %v\n", err)
+		return exitCanceled
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error loading Go project: %v\n", err)
+		return exitLoad
+	}
+	graph := hierarchy.Build(allPkgs)
+
+	var w io.Writer = os.Stdout
+	if *outputFile != "" {
+		f, err := os.Create(*outputFile)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return exitOutput
+		}
+		defer f.Close()
+		w = f
+	}
+
+	if *format == "dot" {
+		err = graph.WriteDOT(w)
+	} else {
+		err = graph.WriteJSON(w)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error writing graph: %v\n", err)
+		return exitOutput
+	}
+	return exitOK
+}
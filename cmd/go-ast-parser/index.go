@@ -0,0 +1,123 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/sunku5494/go-ast-parser/pkg/output"
+	"github.com/sunku5494/go-ast-parser/pkg/types"
+)
+
+// runIndex implements the `index` subcommand, which extracts the project's
+// code chunks and writes them to a JSON file. It is also run when no
+// subcommand is given.
+func runIndex(args []string) int {
+	fs := flag.NewFlagSet("index", flag.ExitOnError)
+	globals := addGlobalFlags(fs)
+	rev := fs.String("rev", "", "Index this git revision of the project instead of the working tree")
+	outputFile := fs.String("output", "", "JSON file the extracted chunks are written to (default code_chunks.json)")
+	extractFlags := addExtractionFlags(fs)
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: %s index [-path /path/to/go/project] [flags]\n", os.Args[0])
+		fs.PrintDefaults()
+	}
+	globals.parse(args)
+
+	if fs.NArg() > 0 {
+		fs.Usage()
+		return exitUsage
+	}
+
+	projectPath, err := globals.projectRoot()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return exitLoad
+	}
+	ex, err := extractFlags.extraction(projectPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return exitLoad
+	}
+	if *outputFile != "" {
+		ex.Output.File = *outputFile
+	}
+
+	globals.progressf("Processing Go project at: %s", projectPath)
+	if *rev == "" && ex.Load.MemoryBudgetMB > 0 {
+		return indexBatches(projectPath, ex, globals)
+	}
+
+	// Steps 1 and 2: Load packages and extract code chunks
+	ctx, cancel := ex.context()
+	var chunks []types.ChromaDocument
+	if *rev != "" {
+		chunks, err = extractChunksAtRevision(ctx, projectPath, *rev, ex)
+	} else {
+		chunks, err = extractChunks(ctx, projectPath, ex)
+	}
+	cancel()
+
+	canceled := isCanceled(err)
+	if err != nil && !canceled {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return exitLoad
+	}
+	if canceled {
+		fmt.Fprintf(os.Stderr, "Stopped: %v\n", err)
+		if len(chunks) == 0 {
+			return exitCanceled
+		}
+		globals.progressf("Writing the %d chunks extracted before the run was stopped", len(chunks))
+	}
+
+	// Step 3: Write chunks to JSON output
+	if err := output.WriteChunksToJSON(chunks, ex.outputFile()); err != nil {
+		fmt.Fprintf(os.Stderr, "Error writing output: %v\n", err)
+		return exitOutput
+	}
+	globals.progressf("Successfully extracted %d code chunks to %s", len(chunks), ex.outputFile())
+	if canceled {
+		return exitCanceled
+	}
+	return exitOK
+}
+
+// indexBatches implements `index` with a memory budget: the chunks of each
+// batch of packages are written as soon as they are extracted, so that
+// neither all syntax trees nor all chunks are held in memory at once.
+func indexBatches(projectPath string, ex extraction, globals *globalFlags) int {
+	w, err := output.CreateChunkWriter(ex.outputFile())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error writing output: %v\n", err)
+		return exitOutput
+	}
+
+	// Steps 1 to 3: Load packages, extract code chunks and write them to JSON output
+	ctx, cancel := ex.context()
+	var writeErr error
+	err = extractBatches(ctx, projectPath, ex, func(chunks []types.ChromaDocument) error {
+		writeErr = w.Write(chunks)
+		return writeErr
+	})
+	cancel()
+	if closeErr := w.Close(); writeErr == nil {
+		writeErr = closeErr
+	}
+
+	if writeErr != nil {
+		fmt.Fprintf(os.Stderr, "Error writing output: %v\n", writeErr)
+		return exitOutput
+	}
+	if isCanceled(err) {
+		fmt.Fprintf(os.Stderr, "Stopped: %v\n", err)
+		globals.progressf("Wrote the %d chunks extracted before the run was stopped to %s", w.Count(), ex.outputFile())
+		return exitCanceled
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return exitLoad
+	}
+	globals.progressf("Successfully extracted %d code chunks to %s", w.Count(), ex.outputFile())
+	return exitOK
+}
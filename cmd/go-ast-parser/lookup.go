@@ -0,0 +1,104 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/sunku5494/go-ast-parser/pkg/index"
+	"github.com/sunku5494/go-ast-parser/pkg/output"
+)
+
+// runLookup implements the `lookup` subcommand, which prints the definition
+// of a fully qualified symbol and optionally the chunks referencing it.
+func runLookup(args []string) int {
+	fs := flag.NewFlagSet("lookup", flag.ExitOnError)
+	globals := addGlobalFlags(fs)
+	indexFile := fs.String("index", "", "Chunk file produced by a previous run (default the configured output file; ignored when -path is given)")
+	showRefs := fs.Bool("refs", false, "Also list chunks whose accessed symbols include the symbol")
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: %s lookup [flags] <import/path.Symbol>\n", os.Args[0])
+		fs.PrintDefaults()
+	}
+	globals.parse(args)
+
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return exitUsage
+	}
+	symbol := fs.Arg(0)
+
+	ix, err := loadIndex(*indexFile, globals)
+	if isCanceled(err) {
+		fmt.Fprintf(os.Stderr, "Stopped: %v\n", err)
+		return exitCanceled
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return exitLoad
+	}
+
+	definitions := ix.Lookup(symbol)
+	if len(definitions) == 0 && !*showRefs {
+		fmt.Fprintf(os.Stderr, "Symbol not found: %s\n", symbol)
+		return exitFailed
+	}
+
+	for _, chunk := range definitions {
+		fmt.Printf("// %s (%s)\n", index.NormalizeSymbol(symbol), index.MetadataString(chunk.Metadata, "entity_type"))
+		fmt.Printf("// %s:%d-%d\n", index.MetadataString(chunk.Metadata, "file_path"),
+			index.MetadataInt(chunk.Metadata, "start_line"), index.MetadataInt(chunk.Metadata, "end_line"))
+		fmt.Println(chunk.Document)
+		fmt.Println()
+	}
+
+	if *showRefs {
+		references := ix.References(symbol)
+		fmt.Printf("// %d references to %s\n", len(references), index.NormalizeSymbol(symbol))
+		for _, chunk := range references {
+			fmt.Printf("%s:%d-%d\t%s\n", index.MetadataString(chunk.Metadata, "file_path"),
+				index.MetadataInt(chunk.Metadata, "start_line"), index.MetadataInt(chunk.Metadata, "end_line"),
+				index.MetadataString(chunk.Metadata, "entity_name"))
+		}
+	}
+
+	return exitOK
+}
+
+// loadIndex builds an index either from the Go module selected by -path,
+// when it is given, or from a previously written chunk file. Without
+// indexFile the chunk file is the one `index` writes: the configuration's
+// output file, or the default.
+func loadIndex(indexFile string, globals *globalFlags) (*index.Index, error) {
+	if globals.pathSet() {
+		projectPath, err := globals.projectRoot()
+		if err != nil {
+			return nil, err
+		}
+		ex, err := loadExtraction(projectPath, "")
+		if err != nil {
+			return nil, err
+		}
+		ctx, cancel := ex.context()
+		defer cancel()
+		chunks, err := extractChunks(ctx, projectPath, ex)
+		if err != nil {
+			return nil, err
+		}
+		return index.New(chunks), nil
+	}
+
+	if indexFile == "" {
+		indexFile = defaultOutputFile
+		if projectPath, err := globals.projectRoot(); err == nil {
+			if ex, err := loadExtraction(projectPath, ""); err == nil {
+				indexFile = ex.outputFile()
+			}
+		}
+	}
+	chunks, err := output.ReadChunksFromJSON(indexFile)
+	if err != nil {
+		return nil, err
+	}
+	return index.New(chunks), nil
+}
@@ -1,61 +1,202 @@
 package main
 
 import (
-	"flag"
+	"context"
+	"errors"
 	"fmt"
-	"log"
 	"os"
 	"path/filepath"
+	"strings"
 
+	"golang.org/x/tools/go/packages"
+
+	"github.com/sunku5494/go-ast-parser/pkg/git"
 	"github.com/sunku5494/go-ast-parser/pkg/loader"
-	"github.com/sunku5494/go-ast-parser/pkg/output"
 	"github.com/sunku5494/go-ast-parser/pkg/parser"
+	"github.com/sunku5494/go-ast-parser/pkg/types"
+)
+
+// version is reported by subcommands that identify the tool to clients.
+const version = "1.0.0"
+
+// Exit codes shared by all subcommands. Invalid flags exit with exitUsage
+// as well, through flag.ExitOnError.
+const (
+	exitOK       = 0
+	exitFailed   = 1 // The command ran but its result is negative, e.g. a symbol was not found
+	exitUsage    = 2 // Invalid flags or arguments
+	exitLoad     = 3 // The project, configuration or an input file could not be loaded
+	exitOutput   = 4 // Results could not be written
+	exitCanceled = 5 // Interrupted or past the -timeout deadline; partial results were written
 )
 
+// command is a subcommand of the CLI.
+type command struct {
+	name    string
+	summary string
+	run     func(args []string) int
+}
+
+// commands lists the subcommands in the order they are shown by usage.
+var commands = []command{
+	{"index", "Extract code chunks to a JSON file (the default command)", runIndex},
+	{"stats", "Extract code chunks and report statistics and phase timings", runStats},
+	{"search", "Search the chunk index by name or content", runSearch},
+	{"lookup", "Print the definition of a symbol and its references", runLookup},
+	{"serve", "Serve the chunk index over a JSON HTTP API", runServe},
+	{"mcp", "Serve the Model Context Protocol over stdio", runMCP},
+	{"watch", "Keep the chunk output up to date while editing", runWatch},
+	{"diff", "Map the changes between git revisions to declarations", runDiff},
+	{"compare", "Report symbols changed between two outputs or revisions", runCompare},
+	{"apicheck", "Check the exported API against a baseline", runAPICheck},
+	{"hierarchy", "Export the type embedding and interface graph", runHierarchy},
+	{"config", "Validate the project configuration file", runConfig},
+	{"schema", "Print the JSON schema of the chunk output", runSchema},
+	{"version", "Print version information", runVersion},
+}
+
 func main() {
-	// Define command-line flag for project path
-	projectPath := flag.String("path", "", "Absolute path to the Go module's root directory (must contain go.mod file)")
-	flag.Parse()
+	os.Exit(run(os.Args[1:]))
+}
 
-	// Validate that project path is provided
-	if *projectPath == "" {
-		fmt.Fprintf(os.Stderr, "Error: Project path is required\n")
-		fmt.Fprintf(os.Stderr, "Usage: %s -path /path/to/go/project\n", os.Args[0])
-		flag.PrintDefaults()
-		os.Exit(1)
+// run dispatches to the subcommand named by the first argument.
+func run(args []string) int {
+	// Without a subcommand, index the project
+	if len(args) == 0 || (strings.HasPrefix(args[0], "-") && !isHelpFlag(args[0])) {
+		return runIndex(args)
 	}
 
-	// Validate that the path exists and contains go.mod
-	if _, err := os.Stat(*projectPath); os.IsNotExist(err) {
-		fmt.Fprintf(os.Stderr, "Error: Project path does not exist: %s\n", *projectPath)
-		os.Exit(1)
+	if args[0] == "help" || isHelpFlag(args[0]) {
+		usage()
+		return exitOK
 	}
+	for _, cmd := range commands {
+		if cmd.name == args[0] {
+			return cmd.run(args[1:])
+		}
+	}
+
+	fmt.Fprintf(os.Stderr, "Error: unknown command %q\n\n", args[0])
+	usage()
+	return exitUsage
+}
 
-	goModPath := filepath.Join(*projectPath, "go.mod")
-	if _, err := os.Stat(goModPath); os.IsNotExist(err) {
-		fmt.Fprintf(os.Stderr, "Error: go.mod file not found in project path: %s\n", *projectPath)
-		fmt.Fprintf(os.Stderr, "Make sure the path points to a Go module root directory\n")
-		os.Exit(1)
+// usage prints the list of subcommands.
+func usage() {
+	fmt.Fprintf(os.Stderr, "Usage: %s <command> [flags]\n\nCommands:\n", os.Args[0])
+	for _, cmd := range commands {
+		fmt.Fprintf(os.Stderr, "  %-10s %s\n", cmd.name, cmd.summary)
 	}
+	fmt.Fprintf(os.Stderr, "\nRun '%s <command> -h' for the flags of a command.\n", os.Args[0])
+	fmt.Fprintf(os.Stderr, "Exit codes: 0 success, 1 negative result, 2 usage error, 3 load error, 4 output error, 5 interrupted.\n")
+}
 
-	fmt.Printf("Processing Go project at: %s\n", *projectPath)
+func isHelpFlag(arg string) bool {
+	return arg == "-h" || arg == "-help" || arg == "--help"
+}
 
-	// Step 1: Load packages from project
-	allPkgs, err := loader.LoadGoProject(*projectPath)
+// findModuleRoot returns the absolute path of the directory containing the
+// go.mod of the module that path belongs to, walking up from path.
+func findModuleRoot(path string) (string, error) {
+	absPath, err := filepath.Abs(path)
 	if err != nil {
-		log.Fatalf("Error loading Go project: %v", err)
+		return "", fmt.Errorf("failed to resolve project path: %w", err)
+	}
+	if _, err := os.Stat(absPath); os.IsNotExist(err) {
+		return "", fmt.Errorf("project path does not exist: %s", path)
+	}
+
+	for dir := absPath; ; dir = filepath.Dir(dir) {
+		if _, err := os.Stat(filepath.Join(dir, "go.mod")); err == nil {
+			return dir, nil
+		}
+		if filepath.Dir(dir) == dir {
+			return "", fmt.Errorf("go.mod file not found in %s or any parent directory (make sure the path is inside a Go module)", path)
+		}
 	}
+}
 
-	// Step 2: Parse packages and extract code chunks
-	chunks, err := parser.ParsePackages(allPkgs, *projectPath)
+// extractChunks loads the project's packages and parses them into code
+// chunks. When ctx is cancelled while parsing, the chunks of the packages
+// completed so far are returned together with the error.
+func extractChunks(ctx context.Context, projectPath string, ex extraction) ([]types.ChromaDocument, error) {
+	var chunks []types.ChromaDocument
+	err := extractBatches(ctx, projectPath, ex, func(batch []types.ChromaDocument) error {
+		chunks = append(chunks, batch...)
+		return nil
+	})
+	return chunks, err
+}
+
+// extractBatches loads and parses the project's packages, passing the chunks
+// to emit. With a memory budget the packages are processed in batches and
+// emit is called once per batch; otherwise it is called once. When ctx is
+// cancelled, the chunks completed so far are emitted before the error is
+// returned.
+func extractBatches(ctx context.Context, projectPath string, ex extraction, emit func([]types.ChromaDocument) error) error {
+	if ex.Load.MemoryBudgetMB <= 0 {
+		// Step 1: Load packages from project
+		allPkgs, err := loader.LoadGoProjectContext(ctx, projectPath, ex.Load)
+		if err != nil {
+			return fmt.Errorf("error loading Go project: %w", err)
+		}
+
+		// Step 2: Parse packages and extract code chunks
+		chunks, err := parser.ParsePackagesContext(ctx, allPkgs, projectPath, ex.Parse)
+		if emitErr := emit(chunks); emitErr != nil {
+			return emitErr
+		}
+		if err != nil {
+			return fmt.Errorf("error parsing packages: %w", err)
+		}
+		return nil
+	}
+
+	// Steps 1 and 2, one batch at a time; errors of emit and parse are
+	// returned as is by LoadBatches
+	batches := parser.NewBatches(projectPath, ex.Parse)
+	var batchErr error
+	err := loader.LoadBatches(ctx, projectPath, ex.Load, func(pkgs []*packages.Package) error {
+		chunks, err := batches.Parse(ctx, pkgs)
+		if batchErr = emit(chunks); batchErr != nil {
+			return batchErr
+		}
+		if err != nil {
+			batchErr = fmt.Errorf("error parsing packages: %w", err)
+		}
+		return batchErr
+	})
+	if err != nil && batchErr == nil {
+		return fmt.Errorf("error loading Go project: %w", err)
+	}
+	return err
+}
+
+// isCanceled reports whether err is due to an interrupt or a deadline.
+func isCanceled(err error) bool {
+	return errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)
+}
+
+// extractChunksAtRevision extracts code chunks from a git revision of the
+// project, materialized into a temporary directory. Chunk paths refer to the
+// project directory.
+func extractChunksAtRevision(ctx context.Context, projectPath, rev string, ex extraction) ([]types.ChromaDocument, error) {
+	absProjectPath, err := filepath.Abs(projectPath)
 	if err != nil {
-		log.Fatalf("Error parsing packages: %v", err)
+		return nil, fmt.Errorf("failed to resolve project path: %w", err)
 	}
 
-	// Step 3: Write chunks to JSON output
-	outputFileName := "code_chunks.json"
-	err = output.WriteChunksToJSON(chunks, outputFileName)
+	revDir, cleanup, err := git.Materialize(absProjectPath, rev)
 	if err != nil {
-		log.Fatalf("Error writing output: %v", err)
+		return nil, fmt.Errorf("error materializing revision %s: %w", rev, err)
+	}
+	defer cleanup()
+
+	chunks, err := extractChunks(ctx, revDir, ex)
+
+	git.RelocateChunks(chunks, revDir, absProjectPath)
+	for _, chunk := range chunks {
+		chunk.Metadata["revision"] = rev
 	}
-} 
\ No newline at end of file
+	return chunks, err
+}
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/sunku5494/go-ast-parser/pkg/hierarchy"
+	"github.com/sunku5494/go-ast-parser/pkg/index"
+	"github.com/sunku5494/go-ast-parser/pkg/loader"
+	"github.com/sunku5494/go-ast-parser/pkg/mcp"
+	"github.com/sunku5494/go-ast-parser/pkg/parser"
+)
+
+// runMCP implements the `mcp` subcommand, which serves the Model Context
+// Protocol over stdio so coding agents can navigate the index.
+func runMCP(args []string) int {
+	fs := flag.NewFlagSet("mcp", flag.ExitOnError)
+	globals := addGlobalFlags(fs)
+	indexFile := fs.String("index", "", "Chunk file produced by a previous run (default the configured output file; ignored when -path is given)")
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: %s mcp [flags]\n", os.Args[0])
+		fs.PrintDefaults()
+	}
+	globals.parse(args)
+
+	// Interface satisfaction needs type information, which is only
+	// available when the project is loaded
+	var ix *index.Index
+	var graph *hierarchy.Graph
+	var err error
+	if globals.pathSet() {
+		ix, graph, err = loadProjectHierarchy(globals)
+	} else {
+		ix, err = loadIndex(*indexFile, globals)
+	}
+	if isCanceled(err) {
+		fmt.Fprintf(os.Stderr, "Stopped: %v\n", err)
+		return exitCanceled
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return exitLoad
+	}
+
+	// stdout carries the protocol; all diagnostics go to stderr
+	server := mcp.New(ix, "go-ast-parser", version)
+	server.Hierarchy = graph
+	if err := server.Serve(os.Stdin, os.Stdout); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return exitFailed
+	}
+	return exitOK
+}
+
+// loadProjectHierarchy builds the index and the type hierarchy of the module
+// selected by -path from a single load of its packages.
+func loadProjectHierarchy(globals *globalFlags) (*index.Index, *hierarchy.Graph, error) {
+	projectPath, err := globals.projectRoot()
+	if err != nil {
+		return nil, nil, err
+	}
+	ex, err := loadExtraction(projectPath, "")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	ctx, cancel := ex.context()
+	defer cancel()
+	allPkgs, err := loader.LoadGoProjectContext(ctx, projectPath, ex.Load)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error loading Go project: %w", err)
+	}
+	chunks, err := parser.ParsePackagesContext(ctx, allPkgs, projectPath, ex.Parse)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error parsing packages: %w", err)
+	}
+	return index.New(chunks), hierarchy.Build(allPkgs), nil
+}
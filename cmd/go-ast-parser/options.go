@@ -0,0 +1,300 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/sunku5494/go-ast-parser/pkg/config"
+	"github.com/sunku5494/go-ast-parser/pkg/loader"
+	"github.com/sunku5494/go-ast-parser/pkg/parser"
+)
+
+// defaultOutputFile is the chunk file written when neither a flag nor the
+// configuration file names one.
+const defaultOutputFile = "code_chunks.json"
+
+// globalFlags are the flags shared by all subcommands.
+type globalFlags struct {
+	fs        *flag.FlagSet
+	path      *string
+	logLevel  *string
+	logFormat *string
+	quiet     *bool
+}
+
+// addGlobalFlags registers the global flags on fs.
+func addGlobalFlags(fs *flag.FlagSet) *globalFlags {
+	return &globalFlags{
+		fs:        fs,
+		path:      fs.String("path", ".", "Directory of the Go module to analyze; the module root is found by walking up to go.mod"),
+		logLevel:  fs.String("log-level", "info", "Minimum level of log messages: debug, info, warn or error"),
+		logFormat: fs.String("log-format", "text", "Log format: text or json"),
+		quiet:     fs.Bool("quiet", false, "Only log errors and suppress progress messages"),
+	}
+}
+
+// parse parses the command line and configures logging. Invalid logging
+// flags exit with exitUsage, like invalid flags do.
+func (g *globalFlags) parse(args []string) {
+	g.fs.Parse(args)
+	if err := g.setupLogging(); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(exitUsage)
+	}
+}
+
+// setupLogging installs the default slog logger writing to stderr.
+func (g *globalFlags) setupLogging() error {
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(*g.logLevel)); err != nil {
+		return fmt.Errorf("invalid -log-level %q (use debug, info, warn or error)", *g.logLevel)
+	}
+	if *g.quiet {
+		level = slog.LevelError
+	}
+
+	handlerOptions := &slog.HandlerOptions{Level: level}
+	var handler slog.Handler
+	switch *g.logFormat {
+	case "text":
+		handler = slog.NewTextHandler(os.Stderr, handlerOptions)
+	case "json":
+		handler = slog.NewJSONHandler(os.Stderr, handlerOptions)
+	default:
+		return fmt.Errorf("invalid -log-format %q (use text or json)", *g.logFormat)
+	}
+	slog.SetDefault(slog.New(handler))
+	return nil
+}
+
+// progressf reports progress to the user on stderr, as a log record when
+// logging JSON. Nothing is printed with -quiet.
+func (g *globalFlags) progressf(format string, args ...interface{}) {
+	switch {
+	case *g.quiet:
+	case *g.logFormat == "json":
+		slog.Info(fmt.Sprintf(format, args...))
+	default:
+		fmt.Fprintf(os.Stderr, format+"\n", args...)
+	}
+}
+
+// pathSet reports whether -path was given on the command line.
+func (g *globalFlags) pathSet() bool {
+	set := false
+	g.fs.Visit(func(fl *flag.Flag) {
+		if fl.Name == "path" {
+			set = true
+		}
+	})
+	return set
+}
+
+// projectRoot returns the root directory of the module containing -path.
+func (g *globalFlags) projectRoot() (string, error) {
+	return findModuleRoot(*g.path)
+}
+
+// extraction holds the loader and parser options of a chunk extraction.
+type extraction struct {
+	Load    loader.Options
+	Parse   parser.Options
+	Output  config.Output
+	Timeout time.Duration // Deadline of the whole extraction; 0 for none
+}
+
+// outputFile returns the configured chunk file, or the default.
+func (ex extraction) outputFile() string {
+	if ex.Output.File != "" {
+		return ex.Output.File
+	}
+	return defaultOutputFile
+}
+
+// context returns the context of an extraction, which is cancelled by
+// SIGINT or SIGTERM and when the timeout expires.
+func (ex extraction) context() (context.Context, context.CancelFunc) {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	if ex.Timeout <= 0 {
+		return ctx, stop
+	}
+	ctx, cancel := context.WithTimeout(ctx, ex.Timeout)
+	return ctx, func() {
+		cancel()
+		stop()
+	}
+}
+
+// extractionFlags are the command-line flags shared by the commands that
+// extract chunks. Flags set on the command line override the project
+// configuration file.
+type extractionFlags struct {
+	fs *flag.FlagSet
+
+	configFile           *string
+	packages             *string
+	tags                 *string
+	tests                *bool
+	skipVendor           *bool
+	include              *string
+	exclude              *string
+	vendorAllow          *string
+	vendorDeny           *string
+	entityTypes          *string
+	excludeEntityTypes   *string
+	minChunkSize         *int
+	vendoredExportedOnly *bool
+	splitValueSpecs      *bool
+	nonGoFiles           *bool
+	excludeGenerated     *bool
+	closureMinLines      *int
+	keepQualifiers       *bool
+	excludeMetadata      *string
+	timeout              *time.Duration
+	packageTimeout       *time.Duration
+	memoryBudget         *int
+}
+
+// addExtractionFlags registers the extraction flags on fs.
+func addExtractionFlags(fs *flag.FlagSet) *extractionFlags {
+	return &extractionFlags{
+		fs:                   fs,
+		configFile:           fs.String("config", "", "Configuration file (default: .go-ast-parser.yaml, .yml or .json at the project root)"),
+		packages:             fs.String("packages", "", "Comma-separated package patterns to load instead of ./..."),
+		tags:                 fs.String("tags", "", "Comma-separated build tags"),
+		tests:                fs.Bool("tests", false, "Also extract test files"),
+		skipVendor:           fs.Bool("skip-vendor", false, "Do not load the vendor directory"),
+		include:              fs.String("include", "", "Comma-separated path globs (relative to the project, ** allowed) of files to extract"),
+		exclude:              fs.String("exclude", "", "Comma-separated path globs of files to skip"),
+		vendorAllow:          fs.String("vendor-allow", "", "Comma-separated vendored module paths to keep (default all)"),
+		vendorDeny:           fs.String("vendor-deny", "", "Comma-separated vendored module paths to drop"),
+		entityTypes:          fs.String("entity-types", "", "Comma-separated entity types to keep (default all)"),
+		excludeEntityTypes:   fs.String("exclude-entity-types", "", "Comma-separated entity types to drop"),
+		minChunkSize:         fs.Int("min-chunk-size", 0, "Drop chunks with fewer bytes of code"),
+		vendoredExportedOnly: fs.Bool("vendored-exported-only", false, "Index only the exported API of vendored packages"),
+		splitValueSpecs:      fs.Bool("split-value-specs", false, "Emit one chunk per name for multi-name const and var declarations"),
+		nonGoFiles:           fs.Bool("non-go-files", false, "Also extract go.mod, go.work, embedded, assembly and .proto files as chunks"),
+		excludeGenerated:     fs.Bool("exclude-generated", false, "Skip generated files (// Code generated ... DO NOT EDIT.)"),
+		closureMinLines:      fs.Int("closure-min-lines", 0, "Extract function literals of at least this many lines as separate chunks (0 disables)"),
+		keepQualifiers:       fs.Bool("keep-qualifiers", false, "Keep package qualifiers in chunk code instead of replacing them with import paths"),
+		excludeMetadata:      fs.String("exclude-metadata", "", "Comma-separated metadata fields to remove from every chunk"),
+		timeout:              fs.Duration("timeout", 0, "Stop the extraction after this long and keep the packages completed so far (0 for no limit)"),
+		packageTimeout:       fs.Duration("package-timeout", 0, "Skip packages that take longer than this to process, checked between declarations (0 for no limit)"),
+		memoryBudget:         fs.Int("memory-budget-mb", 0, "Load and process packages in dependency-ordered batches whose syntax trees fit in about this many MB (0 loads all at once)"),
+	}
+}
+
+// extraction returns the options of the project configuration file with the
+// explicitly set flags applied on top.
+func (f *extractionFlags) extraction(projectPath string) (extraction, error) {
+	ex, err := loadExtraction(projectPath, *f.configFile)
+	if err != nil {
+		return extraction{}, err
+	}
+
+	f.fs.Visit(func(fl *flag.Flag) {
+		switch fl.Name {
+		case "packages":
+			ex.Load.Patterns = splitList(*f.packages)
+		case "tags":
+			ex.Load.BuildTags = splitList(*f.tags)
+		case "tests":
+			ex.Load.Tests = *f.tests
+		case "skip-vendor":
+			ex.Load.SkipVendor = *f.skipVendor
+		case "vendor-allow":
+			ex.Load.VendorAllow = splitList(*f.vendorAllow)
+		case "vendor-deny":
+			ex.Load.VendorDeny = splitList(*f.vendorDeny)
+		case "include":
+			ex.Parse.Filter.IncludePaths = splitList(*f.include)
+		case "exclude":
+			ex.Parse.Filter.ExcludePaths = splitList(*f.exclude)
+		case "entity-types":
+			ex.Parse.Filter.EntityTypes = splitList(*f.entityTypes)
+		case "exclude-entity-types":
+			ex.Parse.Filter.ExcludeEntityTypes = splitList(*f.excludeEntityTypes)
+		case "min-chunk-size":
+			ex.Parse.Filter.MinChunkSize = *f.minChunkSize
+		case "vendored-exported-only":
+			ex.Parse.VendoredExportedOnly = *f.vendoredExportedOnly
+		case "split-value-specs":
+			ex.Parse.SplitValueSpecs = *f.splitValueSpecs
+		case "non-go-files":
+			ex.Parse.NonGoFiles = *f.nonGoFiles
+		case "exclude-generated":
+			ex.Parse.ExcludeGenerated = *f.excludeGenerated
+		case "closure-min-lines":
+			ex.Parse.ClosureMinLines = *f.closureMinLines
+		case "keep-qualifiers":
+			ex.Parse.KeepPackageQualifiers = *f.keepQualifiers
+		case "exclude-metadata":
+			ex.Parse.ExcludeMetadata = splitList(*f.excludeMetadata)
+		case "timeout":
+			ex.Timeout = *f.timeout
+		case "package-timeout":
+			ex.Parse.PackageTimeout = *f.packageTimeout
+		case "memory-budget-mb":
+			ex.Load.MemoryBudgetMB = *f.memoryBudget
+		}
+	})
+	return ex.withPathFilter(), nil
+}
+
+// loadExtraction returns the options of the given configuration file, or of
+// the file discovered at the project root when configFile is empty. Without
+// a configuration file the default options are returned.
+func loadExtraction(projectPath, configFile string) (extraction, error) {
+	if configFile == "" {
+		configFile = config.Discover(projectPath)
+		if configFile == "" {
+			return extraction{}, nil
+		}
+	}
+
+	cfg, err := config.Load(configFile)
+	var unknown *config.UnknownKeysError
+	if errors.As(err, &unknown) {
+		// Unknown keys are most likely typos; run with the known settings
+		// and leave the details to `config validate`
+		slog.Warn("Ignoring unknown configuration keys", "file", unknown.Path, "keys", unknown.Keys)
+	} else if err != nil {
+		return extraction{}, err
+	}
+
+	ex := extraction{
+		Load:   cfg.LoaderOptions(),
+		Parse:  cfg.ParserOptions(),
+		Output: cfg.Output,
+	}
+	return ex.withPathFilter(), nil
+}
+
+// withPathFilter lets the loader skip the packages whose files are all
+// excluded by the path globs, instead of loading them for the parser to drop.
+func (ex extraction) withPathFilter() extraction {
+	ex.Load.IncludeFile = nil
+	if ex.Parse.Filter.HasPathGlobs() {
+		ex.Load.IncludeFile = ex.Parse.Filter.IncludesFile
+	}
+	return ex
+}
+
+// splitList splits a comma-separated flag value, dropping empty items.
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
@@ -0,0 +1,34 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/sunku5494/go-ast-parser/pkg/types"
+)
+
+// runSchema implements the `schema` subcommand, which prints the JSON Schema
+// of the chunk output.
+func runSchema(args []string) int {
+	fs := flag.NewFlagSet("schema", flag.ExitOnError)
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: %s schema\n", os.Args[0])
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+
+	if fs.NArg() > 0 {
+		fs.Usage()
+		return exitUsage
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(types.Schema()); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return exitOutput
+	}
+	return exitOK
+}
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/sunku5494/go-ast-parser/pkg/index"
+)
+
+// runSearch implements the `search` subcommand, which ranks the chunks of
+// the index against a text query.
+func runSearch(args []string) int {
+	fs := flag.NewFlagSet("search", flag.ExitOnError)
+	globals := addGlobalFlags(fs)
+	indexFile := fs.String("index", "", "Chunk file produced by a previous run (default the configured output file; ignored when -path is given)")
+	entityType := fs.String("entity-type", "", "Only return chunks of this entity type")
+	pkgPath := fs.String("package", "", "Only return chunks of this import path")
+	limit := fs.Int("limit", 20, "Maximum number of results (0 for all)")
+	minComplexity := fs.Int("min-complexity", 0, "Only return functions with at least this cyclomatic complexity")
+	sortBy := fs.String("sort", "", "Set to 'complexity' to rank the most complex functions first")
+	excludeGenerated := fs.Bool("exclude-generated", false, "Leave out chunks from generated files")
+	asJSON := fs.Bool("json", false, "Print the results, including code, as JSON")
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: %s search [flags] [query]\n", os.Args[0])
+		fs.PrintDefaults()
+	}
+	globals.parse(args)
+
+	// An empty query lists everything, so require at least one criterion
+	if fs.NArg() == 0 && *entityType == "" && *pkgPath == "" && *minComplexity == 0 {
+		fs.Usage()
+		return exitUsage
+	}
+	if *sortBy != "" && *sortBy != "complexity" {
+		fmt.Fprintf(os.Stderr, "Error: unknown sort order %q (use complexity)\n", *sortBy)
+		return exitUsage
+	}
+
+	ix, err := loadIndex(*indexFile, globals)
+	if isCanceled(err) {
+		fmt.Fprintf(os.Stderr, "Stopped: %v\n", err)
+		return exitCanceled
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return exitLoad
+	}
+
+	results := ix.Search(index.Query{
+		Text:        strings.Join(fs.Args(), " "),
+		EntityType:  *entityType,
+		PackagePath: *pkgPath,
+		Limit:       *limit,
+
+		MinComplexity:    *minComplexity,
+		SortByComplexity: *sortBy == "complexity",
+		ExcludeGenerated: *excludeGenerated,
+	})
+
+	if *asJSON {
+		if results == nil {
+			results = []index.SearchResult{}
+		}
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(results); err != nil {
+			fmt.Fprintf(os.Stderr, "Error encoding results: %v\n", err)
+			return exitOutput
+		}
+	} else {
+		for _, result := range results {
+			metadata := result.Chunk.Metadata
+			fmt.Printf("%d\t%s:%d-%d\t%s (%s)\n", result.Score, index.MetadataString(metadata, "file_path"),
+				index.MetadataInt(metadata, "start_line"), index.MetadataInt(metadata, "end_line"),
+				index.MetadataString(metadata, "qualified_name"), index.MetadataString(metadata, "entity_type"))
+		}
+	}
+
+	if len(results) == 0 {
+		fmt.Fprintf(os.Stderr, "No results\n")
+		return exitFailed
+	}
+	return exitOK
+}
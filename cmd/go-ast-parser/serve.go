@@ -0,0 +1,39 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/sunku5494/go-ast-parser/pkg/server"
+)
+
+// runServe implements the `serve` subcommand, which exposes the chunk index
+// over a JSON HTTP API.
+func runServe(args []string) int {
+	fs := flag.NewFlagSet("serve", flag.ExitOnError)
+	addr := fs.String("addr", "localhost:8080", "Address to listen on")
+	globals := addGlobalFlags(fs)
+	indexFile := fs.String("index", "", "Chunk file produced by a previous run (default the configured output file; ignored when -path is given)")
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: %s serve [flags]\n", os.Args[0])
+		fs.PrintDefaults()
+	}
+	globals.parse(args)
+
+	ix, err := loadIndex(*indexFile, globals)
+	if isCanceled(err) {
+		fmt.Fprintf(os.Stderr, "Stopped: %v\n", err)
+		return exitCanceled
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return exitLoad
+	}
+
+	if err := server.New(ix).ListenAndServe(*addr); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return exitFailed
+	}
+	return exitOK
+}
@@ -0,0 +1,132 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"golang.org/x/tools/go/packages"
+
+	"github.com/sunku5494/go-ast-parser/pkg/loader"
+	"github.com/sunku5494/go-ast-parser/pkg/output"
+	"github.com/sunku5494/go-ast-parser/pkg/parser"
+	"github.com/sunku5494/go-ast-parser/pkg/stats"
+	"github.com/sunku5494/go-ast-parser/pkg/types"
+)
+
+// runStats implements the `stats` subcommand, which extracts code chunks and
+// reports statistics about them together with per-phase timings.
+func runStats(args []string) int {
+	fs := flag.NewFlagSet("stats", flag.ExitOnError)
+	globals := addGlobalFlags(fs)
+	outputFile := fs.String("output", "", "JSON file the extracted chunks are written to (default code_chunks.json)")
+	asJSON := fs.Bool("json", false, "Print the report as JSON")
+	extractFlags := addExtractionFlags(fs)
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: %s stats [-path /path/to/go/project] [-output code_chunks.json] [-json]\n", os.Args[0])
+		fs.PrintDefaults()
+	}
+	globals.parse(args)
+
+	if fs.NArg() > 0 {
+		fs.Usage()
+		return exitUsage
+	}
+	projectPath, err := globals.projectRoot()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return exitLoad
+	}
+
+	timings := stats.NewTimings()
+	var skipped []stats.Skip
+
+	ex, err := extractFlags.extraction(projectPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return exitLoad
+	}
+	if *outputFile != "" {
+		ex.Output.File = *outputFile
+	}
+	ex.Parse.Timings = timings
+	ex.Parse.OnSkip = func(s stats.Skip) { skipped = append(skipped, s) }
+	ex.Load.OnSkip = ex.Parse.OnSkip
+
+	ctx, cancel := ex.context()
+	defer cancel()
+
+	var chunks []types.ChromaDocument
+	if ex.Load.MemoryBudgetMB > 0 {
+		// Loading and parsing alternate between batches, so the load phase
+		// is the time not spent in the parser's phases
+		start := time.Now()
+		before := parserTime(timings)
+		chunks, err = extractChunks(ctx, projectPath, ex)
+		timings.Add("load", time.Since(start)-(parserTime(timings)-before))
+	} else {
+		stopLoad := timings.Track("load")
+		var allPkgs []*packages.Package
+		allPkgs, err = loader.LoadGoProjectContext(ctx, projectPath, ex.Load)
+		stopLoad()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error loading Go project: %v\n", err)
+			if isCanceled(err) {
+				return exitCanceled
+			}
+			return exitLoad
+		}
+		chunks, err = parser.ParsePackagesContext(ctx, allPkgs, projectPath, ex.Parse)
+		if err != nil && !isCanceled(err) {
+			err = fmt.Errorf("error parsing packages: %w", err)
+		}
+	}
+
+	// When stopped, report on the packages completed so far
+	canceled := isCanceled(err)
+	if canceled {
+		fmt.Fprintf(os.Stderr, "Stopped: %v\n", err)
+	} else if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return exitLoad
+	}
+	cancel()
+
+	// Written like index, so that the file holds exactly the reported chunks
+	stopWrite := timings.Track("write")
+	err = output.WriteChunksToJSON(chunks, ex.outputFile())
+	stopWrite()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error writing output: %v\n", err)
+		return exitOutput
+	}
+	globals.progressf("Successfully extracted %d code chunks to %s", len(chunks), ex.outputFile())
+
+	report := stats.Build(chunks, skipped, timings)
+	if *asJSON {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(report); err != nil {
+			fmt.Fprintf(os.Stderr, "Error encoding report: %v\n", err)
+			return exitOutput
+		}
+	} else {
+		report.WriteTable(os.Stdout)
+	}
+
+	if canceled {
+		return exitCanceled
+	}
+	return exitOK
+}
+
+// parserTime returns the total time recorded in the parser's phases.
+func parserTime(timings *stats.Timings) time.Duration {
+	var total time.Duration
+	for _, phase := range []string{"read", "parse", "analyze", "transform"} {
+		total += timings.Get(phase)
+	}
+	return total
+}
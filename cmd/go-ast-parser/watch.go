@@ -0,0 +1,81 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/sunku5494/go-ast-parser/pkg/output"
+	"github.com/sunku5494/go-ast-parser/pkg/watch"
+)
+
+// runWatch implements the `watch` subcommand, which keeps the configured sink
+// up to date while the project is being edited.
+func runWatch(args []string) int {
+	fs := flag.NewFlagSet("watch", flag.ExitOnError)
+	globals := addGlobalFlags(fs)
+	sinkName := fs.String("sink", "", "Where to push updates: 'file' rewrites -output, 'events' streams JSON lines to stdout (default file)")
+	outputFile := fs.String("output", "", "Output file for the 'file' sink (default code_chunks.json)")
+	interval := fs.Duration("interval", 500*time.Millisecond, "How often to scan for changes")
+	debounce := fs.Duration("debounce", time.Second, "Quiet period to wait for before processing changes")
+	extractFlags := addExtractionFlags(fs)
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: %s watch [-path /path/to/go/project] [flags]\n", os.Args[0])
+		fs.PrintDefaults()
+	}
+	globals.parse(args)
+
+	if fs.NArg() > 0 {
+		fs.Usage()
+		return exitUsage
+	}
+	projectPath, err := globals.projectRoot()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return exitLoad
+	}
+
+	ex, err := extractFlags.extraction(projectPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return exitLoad
+	}
+	if *sinkName != "" {
+		ex.Output.Sink = *sinkName
+	}
+	if *outputFile != "" {
+		ex.Output.File = *outputFile
+	}
+
+	var sink output.Sink
+	switch ex.Output.Sink {
+	case "", "file":
+		sink = output.NewJSONFileSink(ex.outputFile())
+	case "events":
+		sink = output.NewEventSink(os.Stdout)
+	default:
+		fmt.Fprintf(os.Stderr, "Error: unknown sink %q\n", ex.Output.Sink)
+		return exitUsage
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	w := watch.New(projectPath, sink)
+	w.Interval = *interval
+	w.Debounce = *debounce
+	w.LoadOptions = ex.Load
+	w.ParseOptions = ex.Parse
+	if err := w.Run(ctx); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return exitFailed
+	}
+	if ctx.Err() != nil {
+		return exitCanceled
+	}
+	return exitOK
+}
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"fmt"
 	"go/ast"
+	"go/constant"
 	"go/printer"
 	"go/token"
 	"go/types"
@@ -105,38 +106,45 @@ func GetSignature(ft *ast.FuncType, info *types.Info) string {
 	return paramStr + resultStr
 }
 
-// ExtractAccessedSymbols inspects a given AST node's subtree and collects all
-// fully qualified symbol paths for imported packages.
+// ExtractAccessedSymbols inspects a given AST node's subtree and collects the
+// fully qualified symbol paths of the package-level entities, methods and
+// struct fields it uses, whether they come from imported packages or from the
+// node's own package. Methods and fields are qualified by their type
+// (`importpath.Type.Method`), like QualifiedName.
 func ExtractAccessedSymbols(node ast.Node, info *types.Info) []string {
 	if node == nil || info == nil {
 		return nil
 	}
-	
+
 	// Using a map to automatically handle duplicate symbols
 	accessed := make(map[string]bool)
+	add := func(symbol string) {
+		if symbol != "" {
+			accessed[symbol] = true
+		}
+	}
 
 	ast.Inspect(node, func(innerNode ast.Node) bool {
-		if selExpr, ok := innerNode.(*ast.SelectorExpr); ok {
-			// A SelectorExpr is of the form `X.Sel`
-			// We check if `X` is an identifier (a package alias)
-			if ident, isIdent := selExpr.X.(*ast.Ident); isIdent {
-				// We use the TypesInfo to get the object that this identifier refers to.
-				obj := info.Uses[ident]
-				if obj == nil {
-					return true // Continue inspection
-				}
-				
-				// If the object is a package name (an import alias)
-				if pkgName, isPkgName := obj.(*types.PkgName); isPkgName {
-					// Get the full import path and the symbol name
-					fullImportPath := pkgName.Imported().Path()
-					symbolName := selExpr.Sel.Name
-					
-					// Construct the fully qualified symbol path
-					fullyQualifiedSymbol := fullImportPath + "." + symbolName
-					accessed[fullyQualifiedSymbol] = true
+		switch n := innerNode.(type) {
+		case *ast.SelectorExpr:
+			// Fields and methods are qualified by the type they are selected
+			// from, which for promoted members is the embedded type
+			if sel, ok := info.Selections[n]; ok {
+				add(selectionSymbol(sel))
+			}
+		case *ast.CompositeLit:
+			// Keys of struct literals are fields of the literal's type
+			for _, elt := range n.Elts {
+				if kv, ok := elt.(*ast.KeyValueExpr); ok {
+					if key, ok := kv.Key.(*ast.Ident); ok {
+						if field, ok := info.Uses[key].(*types.Var); ok && field.IsField() {
+							add(fieldSymbol(info.TypeOf(n), field.Name()))
+						}
+					}
 				}
 			}
+		case *ast.Ident:
+			add(objectSymbol(info.Uses[n]))
 		}
 		return true // Continue inspecting the child nodes
 	})
@@ -149,4 +157,223 @@ func ExtractAccessedSymbols(node ast.Node, info *types.Info) []string {
 	sort.Strings(result)
 
 	return result
-} 
\ No newline at end of file
+}
+
+// objectSymbol returns the qualified name of a package-level object, or of
+// a method. Local variables, fields, builtins and package names yield "".
+func objectSymbol(obj types.Object) string {
+	if obj == nil || obj.Pkg() == nil {
+		return ""
+	}
+	if fn, ok := obj.(*types.Func); ok {
+		if fn.Type().(*types.Signature).Recv() != nil {
+			return memberSymbol(fn)
+		}
+		obj = fn.Origin() // Instantiations of generic functions have no scope
+	}
+	switch obj.(type) {
+	case *types.Func, *types.Var, *types.Const, *types.TypeName:
+		if obj.Parent() == obj.Pkg().Scope() {
+			return QualifiedName(obj.Pkg().Path(), "", obj.Name())
+		}
+	}
+	return ""
+}
+
+// selectionSymbol returns the qualified name of a selected method or field.
+// Promoted fields are qualified by the embedded struct that declares them.
+func selectionSymbol(sel *types.Selection) string {
+	if sel.Kind() != types.FieldVal {
+		return memberSymbol(sel.Obj())
+	}
+	t := sel.Recv()
+	index := sel.Index()
+	for _, i := range index[:len(index)-1] {
+		if ptr, ok := t.Underlying().(*types.Pointer); ok {
+			t = ptr.Elem()
+		}
+		st, ok := t.Underlying().(*types.Struct)
+		if !ok {
+			return ""
+		}
+		t = st.Field(i).Type()
+	}
+	return fieldSymbol(t, sel.Obj().Name())
+}
+
+// memberSymbol returns the qualified name of a method, or "" for fields,
+// which are resolved through the type they are selected from.
+func memberSymbol(obj types.Object) string {
+	fn, ok := obj.(*types.Func)
+	if !ok || fn.Pkg() == nil {
+		return ""
+	}
+	fn = fn.Origin()
+	recv := fn.Type().(*types.Signature).Recv()
+	if recv == nil {
+		return ""
+	}
+	named := namedType(recv.Type())
+	if named == nil {
+		return ""
+	}
+	return QualifiedName(fn.Pkg().Path(), named.Obj().Name(), fn.Name())
+}
+
+// fieldSymbol returns the qualified name of a field of a named struct type.
+func fieldSymbol(t types.Type, field string) string {
+	named := namedType(t)
+	if named == nil || named.Obj().Pkg() == nil {
+		return ""
+	}
+	return QualifiedName(named.Obj().Pkg().Path(), named.Obj().Name(), field)
+}
+
+// namedType returns the named type of t or of the type t points to.
+func namedType(t types.Type) *types.Named {
+	if ptr, ok := types.Unalias(t).(*types.Pointer); ok {
+		t = ptr.Elem()
+	}
+	named, _ := types.Unalias(t).(*types.Named)
+	if named != nil {
+		named = named.Origin()
+	}
+	return named
+}
+
+// ReceiverTypeName returns the base type name of a method receiver expression,
+// stripping pointers and type parameters (e.g. `*List[T]` becomes `List`).
+func ReceiverTypeName(expr ast.Expr) string {
+	switch t := expr.(type) {
+	case *ast.Ident:
+		return t.Name
+	case *ast.StarExpr:
+		return ReceiverTypeName(t.X)
+	case *ast.ParenExpr:
+		return ReceiverTypeName(t.X)
+	case *ast.IndexExpr:
+		return ReceiverTypeName(t.X)
+	case *ast.IndexListExpr:
+		return ReceiverTypeName(t.X)
+	default:
+		return ""
+	}
+}
+
+// QualifiedName builds the fully qualified symbol path for a package-level entity,
+// using the same `importpath.Name` format as ExtractAccessedSymbols. Methods are
+// qualified by their receiver's base type name (`importpath.Type.Method`).
+func QualifiedName(pkgPath, receiverName, name string) string {
+	if receiverName != "" {
+		name = receiverName + "." + name
+	}
+	if pkgPath == "" {
+		return name
+	}
+	return pkgPath + "." + name
+}
+
+// ObjectSignature returns a fully qualified description of a declared object's
+// API: the signature of a function or method, the type of a variable, the type
+// and value of a constant, or the definition of a named type. Parameter and
+// result names of function types are left out, so two objects with the same
+// signature string are interchangeable for callers.
+func ObjectSignature(obj types.Object) string {
+	switch o := obj.(type) {
+	case *types.Func:
+		sig := o.Type().(*types.Signature)
+		if recv := sig.Recv(); recv != nil {
+			return "(" + types.TypeString(recv.Type(), nil) + ") " + TypeString(sig)
+		}
+		return TypeString(sig)
+	case *types.Var:
+		return TypeString(o.Type())
+	case *types.Const:
+		return types.TypeString(o.Type(), nil) + " = " + o.Val().ExactString()
+	case *types.TypeName:
+		if o.IsAlias() {
+			return "= " + TypeString(types.Unalias(o.Type()))
+		}
+		named, ok := o.Type().(*types.Named)
+		if !ok {
+			return TypeString(o.Type().Underlying())
+		}
+		var b strings.Builder
+		if tparams := named.TypeParams(); tparams.Len() > 0 {
+			b.WriteString("[")
+			for i := 0; i < tparams.Len(); i++ {
+				if i > 0 {
+					b.WriteString(", ")
+				}
+				tp := tparams.At(i)
+				b.WriteString(tp.Obj().Name() + " " + types.TypeString(tp.Constraint(), nil))
+			}
+			b.WriteString("] ")
+		}
+		b.WriteString(TypeString(named.Underlying()))
+		return b.String()
+	default:
+		return ""
+	}
+}
+
+// TypeString formats t like types.TypeString, but without the parameter and
+// result names of a function type or of an interface's methods, which do not
+// affect callers.
+func TypeString(t types.Type) string {
+	switch u := t.(type) {
+	case *types.Signature:
+		return "func" + SignatureString(u, nil)
+	case *types.Interface:
+		if u.NumExplicitMethods() == 0 {
+			return types.TypeString(u, nil)
+		}
+		var elems []string
+		for i := 0; i < u.NumEmbeddeds(); i++ {
+			elems = append(elems, types.TypeString(u.EmbeddedType(i), nil))
+		}
+		for i := 0; i < u.NumExplicitMethods(); i++ {
+			method := u.ExplicitMethod(i)
+			elems = append(elems, method.Name()+SignatureString(method.Type().(*types.Signature), nil))
+		}
+		return "interface{" + strings.Join(elems, "; ") + "}"
+	default:
+		return types.TypeString(t, nil)
+	}
+}
+
+// SignatureString formats a signature without the `func` keyword, the
+// receiver and the parameter and result names, qualifying package names
+// with qf. Renaming a parameter thus leaves the result unchanged.
+func SignatureString(sig *types.Signature, qf types.Qualifier) string {
+	var tparams string
+	if tps := sig.TypeParams(); tps.Len() > 0 {
+		var params []string
+		for i := 0; i < tps.Len(); i++ {
+			tp := tps.At(i)
+			params = append(params, tp.Obj().Name()+" "+types.TypeString(tp.Constraint(), qf))
+		}
+		tparams = "[" + strings.Join(params, ", ") + "]"
+	}
+
+	unnamed := types.NewSignatureType(nil, nil, nil, unnamedTuple(sig.Params()), unnamedTuple(sig.Results()), sig.Variadic())
+	return tparams + strings.TrimPrefix(types.TypeString(unnamed, qf), "func")
+}
+
+// unnamedTuple returns a copy of the tuple with all variable names removed.
+func unnamedTuple(tuple *types.Tuple) *types.Tuple {
+	vars := make([]*types.Var, tuple.Len())
+	for i := range vars {
+		vars[i] = types.NewParam(token.NoPos, nil, "", tuple.At(i).Type())
+	}
+	return types.NewTuple(vars...)
+}
+
+// ConstantValue returns the value of a constant as Go source. Floating-point
+// values are rounded rather than given as exact fractions.
+func ConstantValue(c *types.Const) string {
+	if c.Val().Kind() == constant.Float {
+		return c.Val().String()
+	}
+	return c.Val().ExactString()
+}
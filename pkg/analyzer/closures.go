@@ -0,0 +1,37 @@
+package analyzer
+
+import (
+	"go/ast"
+	"go/types"
+	"sort"
+)
+
+// CapturedVariables returns the names of the local variables a function
+// literal refers to but does not declare, i.e. its free variables. Package
+// level variables are not captured and are not included.
+func CapturedVariables(lit *ast.FuncLit, info *types.Info) []string {
+	seen := make(map[string]bool)
+	var captured []string
+
+	ast.Inspect(lit.Body, func(n ast.Node) bool {
+		ident, ok := n.(*ast.Ident)
+		if !ok {
+			return true
+		}
+		v, ok := info.Uses[ident].(*types.Var)
+		if !ok || v.IsField() || v.Pkg() == nil || v.Parent() == nil || v.Parent() == v.Pkg().Scope() {
+			return true
+		}
+		if v.Pos() >= lit.Pos() && v.Pos() < lit.End() {
+			return true // Declared inside the literal
+		}
+		if !seen[v.Name()] {
+			seen[v.Name()] = true
+			captured = append(captured, v.Name())
+		}
+		return true
+	})
+
+	sort.Strings(captured)
+	return captured
+}
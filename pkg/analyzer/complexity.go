@@ -0,0 +1,204 @@
+package analyzer
+
+import (
+	"go/ast"
+	"go/token"
+)
+
+// FunctionMetrics holds code quality metrics for a single function or function literal.
+type FunctionMetrics struct {
+	CyclomaticComplexity int // 1 + number of decision points
+	CognitiveComplexity  int // Control flow breaks weighted by nesting
+	MaxNestingDepth      int // Deepest nesting of control structures and closures
+	StatementCount       int
+	ParameterCount       int
+	LinesOfCode          int
+}
+
+// ComputeFunctionMetrics computes metrics for a function with the given name,
+// type and body, spanning the source range node. The name is used to detect
+// direct recursion and may be empty for function literals.
+func ComputeFunctionMetrics(fset *token.FileSet, node ast.Node, name string, funcType *ast.FuncType, body *ast.BlockStmt) FunctionMetrics {
+	metrics := FunctionMetrics{
+		CyclomaticComplexity: 1,
+		LinesOfCode:          fset.Position(node.End()).Line - fset.Position(node.Pos()).Line + 1,
+	}
+
+	if funcType != nil && funcType.Params != nil {
+		for _, field := range funcType.Params.List {
+			if len(field.Names) == 0 {
+				metrics.ParameterCount++
+			} else {
+				metrics.ParameterCount += len(field.Names)
+			}
+		}
+	}
+
+	if body == nil {
+		return metrics
+	}
+
+	ast.Inspect(body, func(n ast.Node) bool {
+		switch s := n.(type) {
+		case *ast.IfStmt, *ast.ForStmt, *ast.RangeStmt:
+			metrics.CyclomaticComplexity++
+		case *ast.CaseClause:
+			if s.List != nil {
+				metrics.CyclomaticComplexity++
+			}
+		case *ast.CommClause:
+			if s.Comm != nil {
+				metrics.CyclomaticComplexity++
+			}
+		case *ast.BinaryExpr:
+			if s.Op == token.LAND || s.Op == token.LOR {
+				metrics.CyclomaticComplexity++
+			}
+		}
+
+		switch n.(type) {
+		case nil, *ast.BlockStmt, *ast.EmptyStmt, *ast.CaseClause, *ast.CommClause:
+		case ast.Stmt:
+			metrics.StatementCount++
+		}
+		return true
+	})
+
+	c := &cognitiveWalker{funcName: name}
+	c.walk(body, 0)
+	metrics.CognitiveComplexity = c.score
+	metrics.MaxNestingDepth = c.maxNesting
+
+	return metrics
+}
+
+// cognitiveWalker computes cognitive complexity: every break in linear control
+// flow adds one, plus the current nesting level for nestable structures.
+type cognitiveWalker struct {
+	funcName   string
+	score      int
+	maxNesting int
+}
+
+// walk visits node at the given nesting level.
+func (c *cognitiveWalker) walk(node ast.Node, nesting int) {
+	if node == nil {
+		return
+	}
+
+	ast.Inspect(node, func(n ast.Node) bool {
+		switch s := n.(type) {
+		case *ast.IfStmt:
+			c.score += 1 + nesting
+			c.ifStmt(s, nesting)
+			return false
+		case *ast.ForStmt:
+			c.score += 1 + nesting
+			c.walk(s.Init, nesting)
+			c.walk(s.Cond, nesting)
+			c.walk(s.Post, nesting)
+			c.nested(s.Body, nesting)
+			return false
+		case *ast.RangeStmt:
+			c.score += 1 + nesting
+			c.walk(s.X, nesting)
+			c.nested(s.Body, nesting)
+			return false
+		case *ast.SwitchStmt:
+			c.score += 1 + nesting
+			c.walk(s.Init, nesting)
+			c.walk(s.Tag, nesting)
+			c.nested(s.Body, nesting)
+			return false
+		case *ast.TypeSwitchStmt:
+			c.score += 1 + nesting
+			c.walk(s.Init, nesting)
+			c.walk(s.Assign, nesting)
+			c.nested(s.Body, nesting)
+			return false
+		case *ast.SelectStmt:
+			c.score += 1 + nesting
+			c.nested(s.Body, nesting)
+			return false
+		case *ast.FuncLit:
+			c.nested(s.Body, nesting)
+			return false
+		case *ast.BranchStmt:
+			if s.Tok == token.GOTO || s.Label != nil {
+				c.score++
+			}
+		case *ast.BinaryExpr:
+			if s.Op == token.LAND || s.Op == token.LOR {
+				c.logicalExpr(s, nesting)
+				return false
+			}
+		case *ast.CallExpr:
+			if ident, ok := s.Fun.(*ast.Ident); ok && c.funcName != "" && ident.Name == c.funcName {
+				c.score++ // Direct recursion
+			}
+		}
+		return true
+	})
+}
+
+// nested visits the body of a structure that increases the nesting level.
+func (c *cognitiveWalker) nested(body ast.Node, nesting int) {
+	if nesting+1 > c.maxNesting {
+		c.maxNesting = nesting + 1
+	}
+	c.walk(body, nesting+1)
+}
+
+// ifStmt visits an if statement whose own increment was already counted.
+// `else if` and `else` branches add one each, without a nesting penalty.
+func (c *cognitiveWalker) ifStmt(s *ast.IfStmt, nesting int) {
+	c.walk(s.Init, nesting)
+	c.walk(s.Cond, nesting)
+	c.nested(s.Body, nesting)
+
+	switch e := s.Else.(type) {
+	case *ast.IfStmt:
+		c.score++
+		c.ifStmt(e, nesting)
+	case *ast.BlockStmt:
+		c.score++
+		c.nested(e, nesting)
+	}
+}
+
+// logicalExpr adds one for each sequence of like boolean operators in a
+// chain such as `a && b || c`, then visits the chain's operands.
+func (c *cognitiveWalker) logicalExpr(expr *ast.BinaryExpr, nesting int) {
+	var ops []token.Token
+	var operands []ast.Expr
+	flattenLogical(expr, &ops, &operands)
+
+	for i, op := range ops {
+		if i == 0 || op != ops[i-1] {
+			c.score++
+		}
+	}
+	for _, operand := range operands {
+		c.walk(operand, nesting)
+	}
+}
+
+// flattenLogical collects the operators and operands of a boolean
+// expression chain in source order, looking through parentheses.
+func flattenLogical(expr ast.Expr, ops *[]token.Token, operands *[]ast.Expr) {
+	switch e := expr.(type) {
+	case *ast.ParenExpr:
+		if inner, ok := e.X.(*ast.BinaryExpr); ok && (inner.Op == token.LAND || inner.Op == token.LOR) {
+			flattenLogical(inner, ops, operands)
+			return
+		}
+	case *ast.BinaryExpr:
+		if e.Op == token.LAND || e.Op == token.LOR {
+			flattenLogical(e.X, ops, operands)
+			*ops = append(*ops, e.Op)
+			flattenLogical(e.Y, ops, operands)
+			return
+		}
+	}
+	*operands = append(*operands, expr)
+}
@@ -0,0 +1,75 @@
+package analyzer
+
+import (
+	"go/ast"
+	"go/token"
+	"strings"
+)
+
+// Directive is a machine-readable comment such as `//go:generate ...`,
+// `//go:noinline` or `//nolint:errcheck`.
+type Directive struct {
+	Name string `json:"name"`           // e.g. "go:generate" or "nolint"
+	Args string `json:"args,omitempty"` // Text after the name
+	Line int    `json:"line"`
+}
+
+// ParseDirective parses a single `//` comment as a directive. Directives have
+// no space after the slashes and are either of the `prefix:name` form used by
+// the Go toolchain and linters, `//nolint` or a cgo `//export`.
+func ParseDirective(comment string) (Directive, bool) {
+	text, ok := strings.CutPrefix(comment, "//")
+	if !ok || text == "" || text[0] == ' ' || text[0] == '\t' {
+		return Directive{}, false
+	}
+
+	name, args, _ := strings.Cut(text, " ")
+	args = strings.TrimSpace(args)
+
+	switch {
+	case name == "nolint" || strings.HasPrefix(name, "nolint:"):
+		// Linters are listed after the colon: //nolint:errcheck,gosec
+		if linters, ok := strings.CutPrefix(name, "nolint:"); ok {
+			args = strings.TrimSpace(linters + " " + args)
+		}
+		return Directive{Name: "nolint", Args: args}, true
+	case name == "export" || name == "extern":
+		return Directive{Name: name, Args: args}, true
+	}
+
+	prefix, suffix, found := strings.Cut(name, ":")
+	if !found || !isDirectiveWord(prefix) || !isDirectiveWord(suffix) {
+		return Directive{}, false
+	}
+	return Directive{Name: name, Args: args}, true
+}
+
+// isDirectiveWord reports whether s is a non-empty run of lowercase letters and digits.
+func isDirectiveWord(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if (r < 'a' || r > 'z') && (r < '0' || r > '9') {
+			return false
+		}
+	}
+	return true
+}
+
+// Directives returns the directives found in the given comment groups, in order.
+func Directives(fset *token.FileSet, groups ...*ast.CommentGroup) []Directive {
+	var directives []Directive
+	for _, group := range groups {
+		if group == nil {
+			continue
+		}
+		for _, comment := range group.List {
+			if directive, ok := ParseDirective(comment.Text); ok {
+				directive.Line = fset.Position(comment.Pos()).Line
+				directives = append(directives, directive)
+			}
+		}
+	}
+	return directives
+}
@@ -0,0 +1,45 @@
+package analyzer
+
+import (
+	"go/ast"
+	"strings"
+)
+
+// DeprecationMessage returns the text of the "Deprecated: " paragraph of a
+// doc comment, following the Go convention, and whether one was found.
+func DeprecationMessage(doc *ast.CommentGroup) (string, bool) {
+	if doc == nil {
+		return "", false
+	}
+
+	for _, paragraph := range strings.Split(doc.Text(), "\n\n") {
+		paragraph = strings.TrimSpace(paragraph)
+		if message, ok := strings.CutPrefix(paragraph, "Deprecated: "); ok {
+			return strings.Join(strings.Fields(message), " "), true
+		}
+	}
+	return "", false
+}
+
+// GeneratedBy reports whether a file is generated code, following the
+// `// Code generated ... DO NOT EDIT.` convention, and returns the generator
+// named in the header (`Code generated by NAME ...`), if any.
+func GeneratedBy(file *ast.File) (string, bool) {
+	if !ast.IsGenerated(file) {
+		return "", false
+	}
+
+	for _, group := range file.Comments {
+		if group.Pos() >= file.Package {
+			break
+		}
+		for _, comment := range group.List {
+			if rest, ok := strings.CutPrefix(comment.Text, "// Code generated by "); ok {
+				if fields := strings.Fields(rest); len(fields) > 0 {
+					return strings.TrimSuffix(fields[0], "."), true
+				}
+			}
+		}
+	}
+	return "", true
+}
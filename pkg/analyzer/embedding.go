@@ -0,0 +1,165 @@
+package analyzer
+
+import (
+	"go/types"
+	"sort"
+)
+
+// EmbeddedType describes a type embedded in a struct or interface.
+type EmbeddedType struct {
+	Type      string `json:"type"`
+	IsPointer bool   `json:"is_pointer"`
+}
+
+// PromotedMember is a field or method reachable through an embedded type.
+type PromotedMember struct {
+	Name string `json:"name"`
+	Type string `json:"type"` // Field type or method signature
+	From string `json:"from"` // Type declaring the member
+}
+
+// EmbeddedTypes returns the named types embedded in the struct or interface
+// underlying t. Type set terms of constraint interfaces are not included.
+func EmbeddedTypes(t types.Type) []EmbeddedType {
+	var embedded []EmbeddedType
+	switch u := t.Underlying().(type) {
+	case *types.Struct:
+		for i := 0; i < u.NumFields(); i++ {
+			field := u.Field(i)
+			if !field.Embedded() {
+				continue
+			}
+			fieldType := field.Type()
+			ptr, isPointer := fieldType.(*types.Pointer)
+			if isPointer {
+				fieldType = ptr.Elem()
+			}
+			embedded = append(embedded, EmbeddedType{Type: types.TypeString(fieldType, nil), IsPointer: isPointer})
+		}
+	case *types.Interface:
+		for i := 0; i < u.NumEmbeddeds(); i++ {
+			if _, isNamed := types.Unalias(u.EmbeddedType(i)).(*types.Named); isNamed {
+				embedded = append(embedded, EmbeddedType{Type: types.TypeString(u.EmbeddedType(i), nil)})
+			}
+		}
+	}
+	return embedded
+}
+
+// PromotedMethods returns the methods of the struct type t, or of *t, that
+// are promoted from embedded types, sorted by name.
+func PromotedMethods(t types.Type) []PromotedMember {
+	if _, isStruct := t.Underlying().(*types.Struct); !isStruct {
+		return nil
+	}
+
+	var promoted []PromotedMember
+	methods := types.NewMethodSet(types.NewPointer(t))
+	for i := 0; i < methods.Len(); i++ {
+		sel := methods.At(i)
+		if len(sel.Index()) < 2 || !accessible(sel.Obj(), t) {
+			continue // Declared directly on t, or unexported in another package
+		}
+		from := ""
+		if recv := sel.Obj().Type().(*types.Signature).Recv(); recv != nil {
+			from = types.TypeString(derefType(recv.Type()), nil)
+		}
+		promoted = append(promoted, PromotedMember{
+			Name: sel.Obj().Name(),
+			Type: types.TypeString(sel.Type(), nil),
+			From: from,
+		})
+	}
+	return promoted
+}
+
+// PromotedFields returns the fields of the struct type t that are promoted
+// from embedded structs, sorted by name. Fields hidden by a shallower field of
+// the same name, or ambiguous at their depth, are not promoted.
+func PromotedFields(t types.Type) []PromotedMember {
+	st, ok := t.Underlying().(*types.Struct)
+	if !ok {
+		return nil
+	}
+
+	seen := make(map[string]bool)
+	for i := 0; i < st.NumFields(); i++ {
+		seen[st.Field(i).Name()] = true
+	}
+	// Methods hide promoted fields of the same name as well
+	methods := types.NewMethodSet(types.NewPointer(t))
+	for i := 0; i < methods.Len(); i++ {
+		seen[methods.At(i).Obj().Name()] = true
+	}
+
+	var promoted []PromotedMember
+	visited := make(map[types.Type]bool)
+	current := embeddedFieldTypes(st)
+	for len(current) > 0 {
+		found := make(map[string][]PromotedMember)
+		var next []types.Type
+		for _, embedded := range current {
+			embeddedStruct, ok := embedded.Underlying().(*types.Struct)
+			if !ok || visited[embedded] {
+				continue
+			}
+			visited[embedded] = true
+
+			for i := 0; i < embeddedStruct.NumFields(); i++ {
+				field := embeddedStruct.Field(i)
+				if seen[field.Name()] || !accessible(field, t) {
+					continue
+				}
+				found[field.Name()] = append(found[field.Name()], PromotedMember{
+					Name: field.Name(),
+					Type: types.TypeString(field.Type(), nil),
+					From: types.TypeString(embedded, nil),
+				})
+			}
+			next = append(next, embeddedFieldTypes(embeddedStruct)...)
+		}
+
+		for name, members := range found {
+			seen[name] = true
+			if len(members) == 1 {
+				promoted = append(promoted, members[0])
+			}
+		}
+		current = next
+	}
+
+	sort.Slice(promoted, func(i, j int) bool {
+		return promoted[i].Name < promoted[j].Name
+	})
+	return promoted
+}
+
+// embeddedFieldTypes returns the types of the embedded fields of a struct,
+// with pointers removed.
+func embeddedFieldTypes(st *types.Struct) []types.Type {
+	var embedded []types.Type
+	for i := 0; i < st.NumFields(); i++ {
+		if st.Field(i).Embedded() {
+			embedded = append(embedded, derefType(st.Field(i).Type()))
+		}
+	}
+	return embedded
+}
+
+// accessible reports whether obj can be selected from values of type t,
+// which is not the case for unexported members of other packages.
+func accessible(obj types.Object, t types.Type) bool {
+	if obj.Exported() {
+		return true
+	}
+	named, ok := types.Unalias(t).(*types.Named)
+	return ok && obj.Pkg() == named.Obj().Pkg()
+}
+
+// derefType returns the element type of a pointer type, or t itself.
+func derefType(t types.Type) types.Type {
+	if ptr, ok := t.(*types.Pointer); ok {
+		return ptr.Elem()
+	}
+	return t
+}
@@ -0,0 +1,254 @@
+package apicheck
+
+import (
+	"bufio"
+	"fmt"
+	"go/types"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"golang.org/x/tools/go/packages"
+
+	"github.com/sunku5494/go-ast-parser/pkg/analyzer"
+)
+
+// Features returns the exported API surface of the main-module packages
+// among pkgs as sorted feature lines, in the style of the Go distribution's
+// api/*.txt files:
+//
+//	pkg example.com/foo, func New(string) *Client
+//	pkg example.com/foo, method (*Client) Do(*net/http.Request) error
+//	pkg example.com/foo, type Client struct, Name string
+//
+// Vendored, main and internal packages are skipped, as are test variants and
+// external test packages. Every line describes one aspect of the API, so an
+// incompatible change always removes or alters a line.
+func Features(pkgs []*packages.Package, projectPath string) []string {
+	vendorPrefix := filepath.Join(projectPath, "vendor") + string(filepath.Separator)
+
+	seen := make(map[string]bool)
+	var features []string
+	for _, pkg := range pkgs {
+		if pkg.Types == nil || pkg.Name == "main" || isInternal(pkg.PkgPath) {
+			continue
+		}
+		if pkg.ForTest != "" || strings.HasSuffix(pkg.PkgPath, "_test") {
+			continue
+		}
+		if len(pkg.GoFiles) > 0 && strings.HasPrefix(pkg.GoFiles[0], vendorPrefix) {
+			continue
+		}
+
+		for _, feature := range packageFeatures(pkg.Types) {
+			if !seen[feature] {
+				seen[feature] = true
+				features = append(features, feature)
+			}
+		}
+	}
+
+	sort.Strings(features)
+	return features
+}
+
+// packageFeatures lists the features of a single type-checked package.
+func packageFeatures(pkg *types.Package) []string {
+	qualifier := types.RelativeTo(pkg)
+	typeString := func(t types.Type) string { return types.TypeString(t, qualifier) }
+	prefix := "pkg " + pkg.Path() + ", "
+
+	var features []string
+	scope := pkg.Scope()
+	for _, name := range scope.Names() {
+		obj := scope.Lookup(name)
+		if !obj.Exported() {
+			continue
+		}
+
+		switch o := obj.(type) {
+		case *types.Const:
+			features = append(features, prefix+fmt.Sprintf("const %s %s", name, typeString(o.Type())))
+			features = append(features, prefix+fmt.Sprintf("const %s = %s", name, o.Val().ExactString()))
+		case *types.Var:
+			features = append(features, prefix+fmt.Sprintf("var %s %s", name, typeString(o.Type())))
+		case *types.Func:
+			features = append(features, prefix+"func "+name+analyzer.SignatureString(o.Type().(*types.Signature), qualifier))
+		case *types.TypeName:
+			features = append(features, typeFeatures(prefix, o, qualifier)...)
+		}
+	}
+	return features
+}
+
+// typeFeatures lists the features of an exported named type: its kind, its
+// exported fields or interface methods, and its exported method set.
+func typeFeatures(prefix string, obj *types.TypeName, qualifier types.Qualifier) []string {
+	typeString := func(t types.Type) string { return types.TypeString(t, qualifier) }
+	name := obj.Name()
+
+	if obj.IsAlias() {
+		return []string{prefix + fmt.Sprintf("type %s = %s", name, typeString(types.Unalias(obj.Type())))}
+	}
+
+	named, ok := obj.Type().(*types.Named)
+	if !ok {
+		return nil
+	}
+	if tparams := named.TypeParams(); tparams.Len() > 0 {
+		var params []string
+		for i := 0; i < tparams.Len(); i++ {
+			tp := tparams.At(i)
+			params = append(params, tp.Obj().Name()+" "+typeString(tp.Constraint()))
+		}
+		name += "[" + strings.Join(params, ", ") + "]"
+	}
+
+	var features []string
+	switch u := named.Underlying().(type) {
+	case *types.Struct:
+		features = append(features, prefix+"type "+name+" struct")
+		for i := 0; i < u.NumFields(); i++ {
+			field := u.Field(i)
+			if !field.Exported() {
+				continue
+			}
+			if field.Embedded() {
+				features = append(features, prefix+fmt.Sprintf("type %s struct, embedded %s", name, typeString(field.Type())))
+			} else {
+				features = append(features, prefix+fmt.Sprintf("type %s struct, %s %s", name, field.Name(), typeString(field.Type())))
+			}
+		}
+	case *types.Interface:
+		if !u.IsMethodSet() {
+			// Constraint interfaces are described by their full type set
+			features = append(features, prefix+fmt.Sprintf("type %s %s", name, typeString(u)))
+			break
+		}
+
+		// The full method list is part of the type line: adding a method to an
+		// interface breaks its implementations, so it must alter an existing line
+		var methods []string
+		for i := 0; i < u.NumMethods(); i++ {
+			m := u.Method(i)
+			methods = append(methods, m.Name())
+			features = append(features, prefix+fmt.Sprintf("type %s interface, %s%s", name, m.Name(), analyzer.SignatureString(m.Type().(*types.Signature), qualifier)))
+		}
+		sort.Strings(methods)
+		if len(methods) == 0 {
+			features = append(features, prefix+fmt.Sprintf("type %s interface {}", name))
+		} else {
+			features = append(features, prefix+fmt.Sprintf("type %s interface { %s }", name, strings.Join(methods, ", ")))
+		}
+	default:
+		features = append(features, prefix+fmt.Sprintf("type %s %s", name, typeString(u)))
+	}
+
+	if _, isInterface := named.Underlying().(*types.Interface); !isInterface {
+		features = append(features, methodFeatures(prefix, named, qualifier)...)
+	}
+	return features
+}
+
+// methodFeatures lists the exported methods in the method set of *T,
+// including promoted methods, marking which are also in the method set of T.
+func methodFeatures(prefix string, named *types.Named, qualifier types.Qualifier) []string {
+	valueMethods := make(map[string]bool)
+	valueSet := types.NewMethodSet(named)
+	for i := 0; i < valueSet.Len(); i++ {
+		valueMethods[valueSet.At(i).Obj().Name()] = true
+	}
+
+	var features []string
+	ptrSet := types.NewMethodSet(types.NewPointer(named))
+	for i := 0; i < ptrSet.Len(); i++ {
+		method := ptrSet.At(i).Obj()
+		if !method.Exported() {
+			continue
+		}
+		recv := "*" + named.Obj().Name()
+		if valueMethods[method.Name()] {
+			recv = named.Obj().Name()
+		}
+		sig := ptrSet.At(i).Type().(*types.Signature)
+		features = append(features, prefix+fmt.Sprintf("method (%s) %s%s", recv, method.Name(), analyzer.SignatureString(sig, qualifier)))
+	}
+	return features
+}
+
+func isInternal(pkgPath string) bool {
+	return strings.HasSuffix(pkgPath, "/internal") || strings.Contains(pkgPath, "/internal/") || strings.HasPrefix(pkgPath, "internal/")
+}
+
+// Result is the outcome of checking an API surface against a baseline.
+type Result struct {
+	Incompatible []string `json:"incompatible"` // Baseline features that were removed or changed
+	Added        []string `json:"added"`        // Features new since the baseline
+}
+
+// Compatible reports whether the current API is compatible with the baseline.
+func (r Result) Compatible() bool {
+	return len(r.Incompatible) == 0
+}
+
+// Check compares the current features against a baseline following the Go 1
+// compatibility rules: every baseline feature must still exist unchanged,
+// while new features are allowed.
+func Check(baseline, current []string) Result {
+	currentSet := make(map[string]bool, len(current))
+	for _, feature := range current {
+		currentSet[feature] = true
+	}
+	baselineSet := make(map[string]bool, len(baseline))
+	for _, feature := range baseline {
+		baselineSet[feature] = true
+	}
+
+	var result Result
+	for _, feature := range baseline {
+		if !currentSet[feature] {
+			result.Incompatible = append(result.Incompatible, feature)
+		}
+	}
+	for _, feature := range current {
+		if !baselineSet[feature] {
+			result.Added = append(result.Added, feature)
+		}
+	}
+	return result
+}
+
+// WriteFeatures writes feature lines to a file, one per line.
+func WriteFeatures(features []string, filename string) error {
+	var b strings.Builder
+	for _, feature := range features {
+		b.WriteString(feature)
+		b.WriteByte('\n')
+	}
+	if err := os.WriteFile(filename, []byte(b.String()), 0644); err != nil {
+		return fmt.Errorf("error writing API file: %w", err)
+	}
+	return nil
+}
+
+// ReadFeatures reads feature lines written by WriteFeatures, ignoring blank
+// lines and `#` comments.
+func ReadFeatures(filename string) ([]string, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, fmt.Errorf("error reading API file: %w", err)
+	}
+	defer f.Close()
+
+	var features []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		features = append(features, line)
+	}
+	return features, scanner.Err()
+}
@@ -0,0 +1,185 @@
+package compare
+
+import (
+	"go/token"
+	"sort"
+	"strings"
+
+	"github.com/sunku5494/go-ast-parser/pkg/index"
+	"github.com/sunku5494/go-ast-parser/pkg/types"
+)
+
+// Kinds of symbol changes reported by Compare.
+const (
+	Added             = "added"
+	Removed           = "removed"
+	SignatureChanged  = "signature_changed"
+	BodyChanged       = "body_changed"
+	EntityTypeChanged = "entity_type_changed"
+)
+
+// Change describes how a single symbol differs between two snapshots.
+type Change struct {
+	Symbol        string `json:"symbol"`
+	Kind          string `json:"kind"`
+	EntityType    string `json:"entity_type"`
+	OldEntityType string `json:"old_entity_type,omitempty"` // Set when the entity type changed
+	IsExported    bool   `json:"is_exported"`
+	IsBreaking    bool   `json:"is_breaking"`
+	OldSignature  string `json:"old_signature,omitempty"`
+	NewSignature  string `json:"new_signature,omitempty"`
+}
+
+// Report is the result of comparing two chunk snapshots.
+type Report struct {
+	Changes  []Change `json:"changes"`
+	Added    int      `json:"added"`
+	Removed  int      `json:"removed"`
+	Modified int      `json:"modified"`
+	Breaking int      `json:"breaking"`
+}
+
+// Compare reports the symbols added, removed or modified between two chunk
+// snapshots, keyed by `qualified_name`. Each name of a multi-name const or
+// var spec is compared on its own. A modification is an entity type change
+// when the symbol changed kind, e.g. from var to const, a signature change
+// when the symbols' `signature` metadata differ, and a body change when only
+// the code differs. Removing an exported symbol or changing its entity type
+// or signature is flagged as breaking.
+func Compare(oldChunks, newChunks []types.ChromaDocument) Report {
+	oldSymbols := bySymbol(oldChunks)
+	newSymbols := bySymbol(newChunks)
+
+	var report Report
+	for key, oldSymbol := range oldSymbols {
+		newSymbol, ok := newSymbols[key]
+		if !ok {
+			report.add(newChange(Removed, oldSymbol, nil))
+			continue
+		}
+
+		switch {
+		case oldSymbol.entityType != newSymbol.entityType:
+			report.add(newChange(EntityTypeChanged, oldSymbol, &newSymbol))
+		case oldSymbol.signature != newSymbol.signature:
+			report.add(newChange(SignatureChanged, oldSymbol, &newSymbol))
+		case oldSymbol.chunk.Document != newSymbol.chunk.Document:
+			report.add(newChange(BodyChanged, oldSymbol, &newSymbol))
+		}
+	}
+	for key, newSymbol := range newSymbols {
+		if _, ok := oldSymbols[key]; !ok {
+			report.add(newChange(Added, newSymbol, &newSymbol))
+		}
+	}
+
+	sort.Slice(report.Changes, func(i, j int) bool {
+		return report.Changes[i].Symbol < report.Changes[j].Symbol
+	})
+	return report
+}
+
+// add records a change and updates the summary counters.
+func (r *Report) add(change Change) {
+	r.Changes = append(r.Changes, change)
+	switch change.Kind {
+	case Added:
+		r.Added++
+	case Removed:
+		r.Removed++
+	default:
+		r.Modified++
+	}
+	if change.IsBreaking {
+		r.Breaking++
+	}
+}
+
+// newChange builds a Change from a symbol of the old snapshot, or of the new
+// one for added symbols; newSymbol is nil for removed symbols.
+func newChange(kind string, symbol symbolEntry, newSymbol *symbolEntry) Change {
+	change := Change{
+		Symbol:     symbol.name,
+		Kind:       kind,
+		EntityType: symbol.entityType,
+		IsExported: isExportedSymbol(symbol),
+	}
+	if kind != Added {
+		change.OldSignature = symbol.signature
+	}
+	if newSymbol != nil {
+		change.EntityType = newSymbol.entityType
+		change.NewSignature = newSymbol.signature
+		change.IsExported = isExportedSymbol(*newSymbol)
+	}
+	if kind == EntityTypeChanged {
+		change.OldEntityType = symbol.entityType
+	}
+	change.IsBreaking = change.IsExported && (kind == Removed || kind == SignatureChanged || kind == EntityTypeChanged)
+	return change
+}
+
+// symbolEntry is a symbol defined by a chunk.
+type symbolEntry struct {
+	name       string
+	entityType string
+	signature  string
+	chunk      types.ChromaDocument
+}
+
+// bySymbol maps the qualified names of the symbols defined by the chunks to
+// their entries. Chunks of multi-name specs define one symbol per name,
+// listed in `qualified_names` and `signatures`; chunks without a name are
+// skipped.
+func bySymbol(chunks []types.ChromaDocument) map[string]symbolEntry {
+	symbols := make(map[string]symbolEntry)
+	add := func(name, signature string, chunk types.ChromaDocument) {
+		entityType := index.MetadataString(chunk.Metadata, "entity_type")
+		symbols[name] = symbolEntry{name: name, entityType: entityType, signature: signature, chunk: chunk}
+	}
+
+	for _, chunk := range chunks {
+		if name := index.MetadataString(chunk.Metadata, "qualified_name"); name != "" {
+			add(name, index.MetadataString(chunk.Metadata, "signature"), chunk)
+			continue
+		}
+
+		names := index.MetadataStrings(chunk.Metadata, "qualified_names")
+		signatures := index.MetadataStrings(chunk.Metadata, "signatures")
+		for i, name := range names {
+			if strings.HasSuffix(name, "._") {
+				continue // Blank names declare nothing
+			}
+			signature := ""
+			if i < len(signatures) {
+				signature = signatures[i]
+			}
+			add(name, signature, chunk)
+		}
+	}
+	return symbols
+}
+
+// isExportedSymbol reports whether a symbol is part of its package's
+// importable API; methods also require an exported receiver type, enums an
+// exported type, and symbols of main and internal packages are never
+// considered exported.
+func isExportedSymbol(symbol symbolEntry) bool {
+	pkgPath := index.MetadataString(symbol.chunk.Metadata, "package_path")
+	name := strings.TrimPrefix(symbol.name, pkgPath+".")
+	if symbol.entityType == "enum" {
+		name = strings.TrimSuffix(name, ".enum")
+	}
+	if name == "" || index.MetadataString(symbol.chunk.Metadata, "package_name") == "main" {
+		return false
+	}
+	if strings.Contains(pkgPath, "/internal/") || strings.HasSuffix(pkgPath, "/internal") {
+		return false
+	}
+	for _, part := range strings.Split(name, ".") {
+		if !token.IsExported(part) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,165 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/sunku5494/go-ast-parser/pkg/loader"
+	"github.com/sunku5494/go-ast-parser/pkg/parser"
+)
+
+// FileNames are the configuration file names looked up at the project root,
+// in order of precedence.
+var FileNames = []string{".go-ast-parser.yaml", ".go-ast-parser.yml", ".go-ast-parser.json"}
+
+// Config is the project configuration file. Every setting has a
+// corresponding command-line flag, which takes precedence when set.
+type Config struct {
+	Loader     loader.Options `json:"loader"`
+	Chunking   Chunking       `json:"chunking"`
+	Filter     parser.Filter  `json:"filter"`
+	Transforms Transforms     `json:"transforms"`
+	Metadata   Metadata       `json:"metadata"`
+	Output     Output         `json:"output"`
+}
+
+// Chunking configures how declarations are turned into chunks.
+type Chunking struct {
+	SplitValueSpecs      bool `json:"split_value_specs"`
+	ClosureMinLines      int  `json:"closure_min_lines"`
+	NonGoFiles           bool `json:"non_go_files"`
+	ExcludeGenerated     bool `json:"exclude_generated"`
+	VendoredExportedOnly bool `json:"vendored_exported_only"`
+}
+
+// Transforms configures the code transformations applied to chunks.
+type Transforms struct {
+	// QualifyImports replaces package qualifiers with full import paths;
+	// defaults to true.
+	QualifyImports *bool `json:"qualify_imports"`
+}
+
+// Metadata configures the metadata fields of chunks.
+type Metadata struct {
+	Exclude []string `json:"exclude"` // Fields removed from every chunk
+}
+
+// Output configures where chunks are written.
+type Output struct {
+	File string `json:"file"` // Chunk file, default code_chunks.json
+	Sink string `json:"sink"` // Sink used by watch: file or events
+}
+
+// UnknownKeysError reports keys of a configuration file that do not
+// correspond to any setting.
+type UnknownKeysError struct {
+	Path string
+	Keys []string // Dotted key paths, e.g. chunking.split_specs
+}
+
+func (e *UnknownKeysError) Error() string {
+	return fmt.Sprintf("%s: unknown configuration keys: %s", e.Path, strings.Join(e.Keys, ", "))
+}
+
+// Discover returns the path of the configuration file at the project root,
+// or "" if there is none.
+func Discover(projectPath string) string {
+	for _, name := range FileNames {
+		path := filepath.Join(projectPath, name)
+		if info, err := os.Stat(path); err == nil && !info.IsDir() {
+			return path
+		}
+	}
+	return ""
+}
+
+// Load reads a YAML or JSON configuration file. Files with a .json extension
+// are parsed as JSON, anything else as YAML. Unknown keys are reported as an
+// *UnknownKeysError together with the configuration decoded from the known
+// keys.
+func Load(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading config file: %w", err)
+	}
+
+	var raw map[string]interface{}
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		err = json.Unmarshal(data, &raw)
+	} else {
+		raw, err = parseYAML(data)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error parsing config file %s: %w", path, err)
+	}
+
+	// Round-trip through JSON so that both formats share the struct tags
+	encoded, err := json.Marshal(raw)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing config file %s: %w", path, err)
+	}
+	cfg := &Config{}
+	if err := json.Unmarshal(encoded, cfg); err != nil {
+		return nil, fmt.Errorf("invalid config file %s: %w", path, err)
+	}
+
+	if unknown := unknownKeys(raw, reflect.TypeOf(*cfg), ""); len(unknown) > 0 {
+		sort.Strings(unknown)
+		return cfg, &UnknownKeysError{Path: path, Keys: unknown}
+	}
+	return cfg, nil
+}
+
+// LoaderOptions returns the package loading options.
+func (c *Config) LoaderOptions() loader.Options {
+	return c.Loader
+}
+
+// ParserOptions returns the chunk extraction options.
+func (c *Config) ParserOptions() parser.Options {
+	return parser.Options{
+		VendoredExportedOnly:  c.Chunking.VendoredExportedOnly,
+		SplitValueSpecs:       c.Chunking.SplitValueSpecs,
+		ClosureMinLines:       c.Chunking.ClosureMinLines,
+		NonGoFiles:            c.Chunking.NonGoFiles,
+		ExcludeGenerated:      c.Chunking.ExcludeGenerated,
+		Filter:                c.Filter,
+		KeepPackageQualifiers: c.Transforms.QualifyImports != nil && !*c.Transforms.QualifyImports,
+		ExcludeMetadata:       c.Metadata.Exclude,
+	}
+}
+
+// unknownKeys returns the keys of raw, recursively, that have no matching
+// json tag in the struct type t.
+func unknownKeys(raw map[string]interface{}, t reflect.Type, prefix string) []string {
+	fields := make(map[string]reflect.Type)
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "" || name == "-" || !field.IsExported() {
+			continue
+		}
+		fields[name] = field.Type
+	}
+
+	var unknown []string
+	for key, value := range raw {
+		fieldType, ok := fields[key]
+		if !ok {
+			unknown = append(unknown, prefix+key)
+			continue
+		}
+		for fieldType.Kind() == reflect.Ptr {
+			fieldType = fieldType.Elem()
+		}
+		if nested, ok := value.(map[string]interface{}); ok && fieldType.Kind() == reflect.Struct {
+			unknown = append(unknown, unknownKeys(nested, fieldType, prefix+key+".")...)
+		}
+	}
+	return unknown
+}
@@ -0,0 +1,243 @@
+package config
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// yamlLine is a significant line of a YAML document.
+type yamlLine struct {
+	number int
+	indent int
+	text   string
+}
+
+// parseYAML parses the subset of YAML used by configuration files: nested
+// block mappings, block sequences of scalars, flow sequences (`[a, b]`),
+// quoted and plain scalars and `#` comments. Anchors, multi-line strings and
+// sequences of mappings are not supported.
+func parseYAML(data []byte) (map[string]interface{}, error) {
+	var lines []yamlLine
+	for i, raw := range strings.Split(string(data), "\n") {
+		text := strings.TrimRight(stripYAMLComment(raw), " \t\r")
+		if strings.TrimSpace(text) == "" || text == "---" {
+			continue
+		}
+		if strings.HasPrefix(strings.TrimLeft(text, " "), "\t") {
+			return nil, fmt.Errorf("line %d: tabs are not allowed for indentation", i+1)
+		}
+		trimmed := strings.TrimLeft(text, " ")
+		lines = append(lines, yamlLine{number: i + 1, indent: len(text) - len(trimmed), text: trimmed})
+	}
+
+	p := &yamlParser{lines: lines}
+	if len(lines) == 0 {
+		return map[string]interface{}{}, nil
+	}
+	value, err := p.mapping(lines[0].indent)
+	if err != nil {
+		return nil, err
+	}
+	if p.pos < len(p.lines) {
+		return nil, fmt.Errorf("line %d: unexpected indentation", p.lines[p.pos].number)
+	}
+	return value, nil
+}
+
+// yamlParser consumes lines of a YAML document.
+type yamlParser struct {
+	lines []yamlLine
+	pos   int
+}
+
+// mapping parses a block mapping whose keys are at the given indentation.
+func (p *yamlParser) mapping(indent int) (map[string]interface{}, error) {
+	result := make(map[string]interface{})
+	for p.pos < len(p.lines) {
+		line := p.lines[p.pos]
+		if line.indent < indent {
+			break
+		}
+		if line.indent > indent {
+			return nil, fmt.Errorf("line %d: unexpected indentation", line.number)
+		}
+		if strings.HasPrefix(line.text, "- ") || line.text == "-" {
+			return nil, fmt.Errorf("line %d: expected a key, found a list item", line.number)
+		}
+
+		key, rest, found := strings.Cut(line.text, ":")
+		if !found || (rest != "" && rest[0] != ' ') {
+			return nil, fmt.Errorf("line %d: expected `key: value`", line.number)
+		}
+		key = unquoteYAML(strings.TrimSpace(key))
+		rest = strings.TrimSpace(rest)
+		if _, exists := result[key]; exists {
+			return nil, fmt.Errorf("line %d: duplicate key %q", line.number, key)
+		}
+		p.pos++
+
+		if rest != "" {
+			value, err := scalarOrFlow(rest, line.number)
+			if err != nil {
+				return nil, err
+			}
+			result[key] = value
+			continue
+		}
+
+		// A nested block, or null when nothing more indented follows
+		if p.pos >= len(p.lines) || p.lines[p.pos].indent < indent ||
+			(p.lines[p.pos].indent == indent && !strings.HasPrefix(p.lines[p.pos].text, "-")) {
+			result[key] = nil
+			continue
+		}
+		next := p.lines[p.pos]
+		if strings.HasPrefix(next.text, "- ") || next.text == "-" {
+			value, err := p.sequence(next.indent)
+			if err != nil {
+				return nil, err
+			}
+			result[key] = value
+			continue
+		}
+		value, err := p.mapping(next.indent)
+		if err != nil {
+			return nil, err
+		}
+		result[key] = value
+	}
+	return result, nil
+}
+
+// sequence parses a block sequence of scalars at the given indentation.
+func (p *yamlParser) sequence(indent int) ([]interface{}, error) {
+	result := []interface{}{}
+	for p.pos < len(p.lines) {
+		line := p.lines[p.pos]
+		if line.indent != indent || !(strings.HasPrefix(line.text, "- ") || line.text == "-") {
+			break
+		}
+		item := strings.TrimSpace(strings.TrimPrefix(line.text, "-"))
+		if isYAMLMappingItem(item) {
+			return nil, fmt.Errorf("line %d: lists of mappings are not supported", line.number)
+		}
+		value, err := scalarOrFlow(item, line.number)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, value)
+		p.pos++
+	}
+	return result, nil
+}
+
+// isYAMLMappingItem reports whether a list item starts a mapping (`- key: value`).
+func isYAMLMappingItem(item string) bool {
+	if item == "" || item[0] == '"' || item[0] == '\'' || item[0] == '[' {
+		return false
+	}
+	key, rest, found := strings.Cut(item, ":")
+	return found && key != "" && (rest == "" || rest[0] == ' ')
+}
+
+// scalarOrFlow parses a scalar or a flow sequence of scalars.
+func scalarOrFlow(text string, lineNumber int) (interface{}, error) {
+	if !strings.HasPrefix(text, "[") {
+		if strings.HasPrefix(text, "{") {
+			return nil, fmt.Errorf("line %d: flow mappings are not supported", lineNumber)
+		}
+		return yamlScalar(text), nil
+	}
+	if !strings.HasSuffix(text, "]") {
+		return nil, fmt.Errorf("line %d: unterminated list", lineNumber)
+	}
+
+	items := []interface{}{}
+	inner := strings.TrimSpace(text[1 : len(text)-1])
+	if inner == "" {
+		return items, nil
+	}
+	for _, item := range splitFlow(inner) {
+		items = append(items, yamlScalar(strings.TrimSpace(item)))
+	}
+	return items, nil
+}
+
+// splitFlow splits the items of a flow sequence at commas outside quotes.
+func splitFlow(s string) []string {
+	var items []string
+	var quote byte
+	start := 0
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == ',':
+			items = append(items, s[start:i])
+			start = i + 1
+		}
+	}
+	return append(items, s[start:])
+}
+
+// yamlScalar converts a plain or quoted scalar to a string, bool, number or nil.
+func yamlScalar(text string) interface{} {
+	if len(text) >= 2 && (text[0] == '"' || text[0] == '\'') && text[len(text)-1] == text[0] {
+		return unquoteYAML(text)
+	}
+	switch text {
+	case "", "~", "null", "Null", "NULL":
+		return nil
+	case "true", "True", "TRUE", "yes", "Yes", "YES", "on", "On", "ON":
+		return true
+	case "false", "False", "FALSE", "no", "No", "NO", "off", "Off", "OFF":
+		return false
+	}
+	if n, err := strconv.ParseInt(text, 10, 64); err == nil {
+		return n
+	}
+	if f, err := strconv.ParseFloat(text, 64); err == nil {
+		return f
+	}
+	return text
+}
+
+// unquoteYAML removes the quotes of a quoted scalar.
+func unquoteYAML(text string) string {
+	if len(text) < 2 {
+		return text
+	}
+	switch {
+	case text[0] == '"' && text[len(text)-1] == '"':
+		if s, err := strconv.Unquote(text); err == nil {
+			return s
+		}
+		return text[1 : len(text)-1]
+	case text[0] == '\'' && text[len(text)-1] == '\'':
+		return strings.ReplaceAll(text[1:len(text)-1], "''", "'")
+	}
+	return text
+}
+
+// stripYAMLComment removes a trailing `#` comment outside quotes.
+func stripYAMLComment(line string) string {
+	var quote byte
+	for i := 0; i < len(line); i++ {
+		switch c := line[i]; {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '#' && (i == 0 || line[i-1] == ' ' || line[i-1] == '\t'):
+			return line[:i]
+		}
+	}
+	return line
+}
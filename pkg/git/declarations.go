@@ -0,0 +1,142 @@
+package git
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/sunku5494/go-ast-parser/pkg/loader"
+	"github.com/sunku5494/go-ast-parser/pkg/parser"
+	"github.com/sunku5494/go-ast-parser/pkg/types"
+)
+
+// DeclarationDiff pairs a changed file with the chunks of the declarations
+// enclosing its hunks, before and after the change.
+type DeclarationDiff struct {
+	FileDiff
+	Before []types.ChromaDocument `json:"before"`
+	After  []types.ChromaDocument `json:"after"`
+}
+
+// DiffDeclarations maps the hunks changed between two revisions of the
+// project to their enclosing declarations. An empty toRev compares against
+// the working tree. Only the packages containing changed files are loaded,
+// and chunk paths always refer to the project directory.
+func DiffDeclarations(projectPath, fromRev, toRev string) ([]DeclarationDiff, error) {
+	return DiffDeclarationsWithOptions(projectPath, fromRev, toRev, loader.Options{}, parser.Options{})
+}
+
+// DiffDeclarationsWithOptions is like DiffDeclarations, loading the packages
+// at both revisions with the build tags and test settings of loadOpts and
+// extracting their chunks as configured by parseOpts. The package patterns
+// of loadOpts are replaced by the packages containing changed files.
+func DiffDeclarationsWithOptions(projectPath, fromRev, toRev string, loadOpts loader.Options, parseOpts parser.Options) ([]DeclarationDiff, error) {
+	return DiffDeclarationsContext(context.Background(), projectPath, fromRev, toRev, loadOpts, parseOpts)
+}
+
+// DiffDeclarationsContext is like DiffDeclarationsWithOptions, stopping with
+// the context's error when ctx is cancelled.
+func DiffDeclarationsContext(ctx context.Context, projectPath, fromRev, toRev string, loadOpts loader.Options, parseOpts parser.Options) ([]DeclarationDiff, error) {
+	absProjectPath, err := filepath.Abs(projectPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve project path: %w", err)
+	}
+
+	diffs, err := ChangedFiles(absProjectPath, fromRev, toRev)
+	if err != nil {
+		return nil, err
+	}
+	if len(diffs) == 0 {
+		return nil, nil
+	}
+
+	var oldFiles, newFiles []string
+	for _, d := range diffs {
+		if d.OldPath != "" {
+			oldFiles = append(oldFiles, d.OldPath)
+		}
+		if d.NewPath != "" {
+			newFiles = append(newFiles, d.NewPath)
+		}
+	}
+
+	before, err := chunksAtRevision(ctx, absProjectPath, fromRev, oldFiles, loadOpts, parseOpts)
+	if err != nil {
+		return nil, err
+	}
+	after, err := chunksAtRevision(ctx, absProjectPath, toRev, newFiles, loadOpts, parseOpts)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]DeclarationDiff, 0, len(diffs))
+	for _, d := range diffs {
+		dd := DeclarationDiff{FileDiff: d}
+		if d.OldPath != "" {
+			dd.Before = ChunksInRanges(before, filepath.Join(absProjectPath, d.OldPath), d.OldRanges())
+		}
+		if d.NewPath != "" {
+			dd.After = ChunksInRanges(after, filepath.Join(absProjectPath, d.NewPath), d.NewRanges())
+		}
+		result = append(result, dd)
+	}
+	return result, nil
+}
+
+// chunksAtRevision extracts the chunks of the packages containing the given
+// files (relative to the project) at a revision, or in the working tree
+// when rev is empty.
+func chunksAtRevision(ctx context.Context, projectPath, rev string, files []string, loadOpts loader.Options, parseOpts parser.Options) ([]types.ChromaDocument, error) {
+	if len(files) == 0 {
+		return nil, nil
+	}
+
+	dir := projectPath
+	if rev != "" {
+		tmpDir, cleanup, err := Materialize(projectPath, rev)
+		if err != nil {
+			return nil, err
+		}
+		defer cleanup()
+		dir = tmpDir
+	}
+
+	patterns := packagePatterns(dir, files)
+	if len(patterns) == 0 {
+		return nil, nil
+	}
+
+	pkgs, err := loader.LoadPackagesContext(ctx, dir, loadOpts, patterns...)
+	if err != nil {
+		return nil, err
+	}
+	chunks, err := parser.ParsePackagesContext(ctx, pkgs, dir, parseOpts)
+	if err != nil {
+		return nil, err
+	}
+
+	RelocateChunks(chunks, dir, projectPath)
+	return chunks, nil
+}
+
+// packagePatterns returns one relative directory pattern per existing
+// package directory containing the given files.
+func packagePatterns(dir string, files []string) []string {
+	seen := make(map[string]bool)
+	var patterns []string
+	for _, file := range files {
+		pkgDir := filepath.Dir(filepath.FromSlash(file))
+		if seen[pkgDir] {
+			continue
+		}
+		seen[pkgDir] = true
+		if _, err := os.Stat(filepath.Join(dir, pkgDir)); err != nil {
+			continue
+		}
+		patterns = append(patterns, "./"+filepath.ToSlash(pkgDir))
+	}
+	sort.Strings(patterns)
+	return patterns
+}
@@ -0,0 +1,178 @@
+package git
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/sunku5494/go-ast-parser/pkg/index"
+	"github.com/sunku5494/go-ast-parser/pkg/types"
+)
+
+// Hunk is a changed line range reported by `git diff -U0`. A count of zero
+// means lines were only added (Old) or only removed (New) at that position.
+type Hunk struct {
+	OldStart int `json:"old_start"`
+	OldLines int `json:"old_lines"`
+	NewStart int `json:"new_start"`
+	NewLines int `json:"new_lines"`
+}
+
+// FileDiff lists the hunks changed in one Go file, with paths relative to
+// the project directory. OldPath is empty for added files and NewPath is
+// empty for deleted files.
+type FileDiff struct {
+	OldPath string `json:"old_path,omitempty"`
+	NewPath string `json:"new_path,omitempty"`
+	Hunks   []Hunk `json:"hunks"`
+}
+
+// ChangedFiles returns the Go files changed between two revisions of the
+// project. An empty toRev compares fromRev against the working tree.
+func ChangedFiles(projectPath, fromRev, toRev string) ([]FileDiff, error) {
+	args := []string{"diff", "--relative", "--no-color", "--no-ext-diff", "-U0", fromRev}
+	if toRev != "" {
+		args = append(args, toRev)
+	}
+	args = append(args, "--", "*.go")
+
+	out, err := run(projectPath, args...)
+	if err != nil {
+		return nil, err
+	}
+	return parseDiff(out)
+}
+
+// parseDiff parses unified diff output with zero context lines.
+func parseDiff(out []byte) ([]FileDiff, error) {
+	var diffs []FileDiff
+	var current *FileDiff
+
+	scanner := bufio.NewScanner(bytes.NewReader(out))
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case strings.HasPrefix(line, "diff --git "):
+			diffs = append(diffs, FileDiff{})
+			current = &diffs[len(diffs)-1]
+		case current == nil:
+			continue
+		case strings.HasPrefix(line, "--- "):
+			current.OldPath = diffPath(strings.TrimPrefix(line, "--- "), "a/")
+		case strings.HasPrefix(line, "+++ "):
+			current.NewPath = diffPath(strings.TrimPrefix(line, "+++ "), "b/")
+		case strings.HasPrefix(line, "@@ "):
+			hunk, err := parseHunkHeader(line)
+			if err != nil {
+				return nil, err
+			}
+			current.Hunks = append(current.Hunks, hunk)
+		}
+	}
+	return diffs, scanner.Err()
+}
+
+func diffPath(path, prefix string) string {
+	if path == "/dev/null" {
+		return ""
+	}
+	return strings.TrimPrefix(path, prefix)
+}
+
+// parseHunkHeader parses a header of the form `@@ -a,b +c,d @@`.
+func parseHunkHeader(line string) (Hunk, error) {
+	fields := strings.Fields(line)
+	if len(fields) < 3 {
+		return Hunk{}, fmt.Errorf("invalid hunk header: %s", line)
+	}
+
+	oldStart, oldLines, err := parseRange(strings.TrimPrefix(fields[1], "-"))
+	if err != nil {
+		return Hunk{}, fmt.Errorf("invalid hunk header %q: %w", line, err)
+	}
+	newStart, newLines, err := parseRange(strings.TrimPrefix(fields[2], "+"))
+	if err != nil {
+		return Hunk{}, fmt.Errorf("invalid hunk header %q: %w", line, err)
+	}
+
+	return Hunk{OldStart: oldStart, OldLines: oldLines, NewStart: newStart, NewLines: newLines}, nil
+}
+
+// parseRange parses `start,count`; a missing count means one line.
+func parseRange(s string) (int, int, error) {
+	startStr, countStr, hasCount := strings.Cut(s, ",")
+	start, err := strconv.Atoi(startStr)
+	if err != nil {
+		return 0, 0, err
+	}
+	if !hasCount {
+		return start, 1, nil
+	}
+	count, err := strconv.Atoi(countStr)
+	return start, count, err
+}
+
+// LineRange is a range of changed lines. When Between is set, the range marks
+// an insertion or deletion point between lines Start and End.
+type LineRange struct {
+	Start   int
+	End     int
+	Between bool
+}
+
+// ChunksInRanges returns the chunks of file affected by any of the given
+// ranges. Chunks are matched on their `file_path`, `start_line` and
+// `end_line` metadata, which come from the declarations' AST positions; an
+// insertion point only matches the declarations enclosing it.
+func ChunksInRanges(chunks []types.ChromaDocument, file string, ranges []LineRange) []types.ChromaDocument {
+	var result []types.ChromaDocument
+	for _, chunk := range chunks {
+		if filepath.Clean(index.MetadataString(chunk.Metadata, "file_path")) != filepath.Clean(file) {
+			continue
+		}
+		start := index.MetadataInt(chunk.Metadata, "start_line")
+		end := index.MetadataInt(chunk.Metadata, "end_line")
+		for _, r := range ranges {
+			overlaps := start <= r.End && r.Start <= end
+			if r.Between {
+				overlaps = start <= r.Start && r.End <= end
+			}
+			if overlaps {
+				result = append(result, chunk)
+				break
+			}
+		}
+	}
+	return result
+}
+
+// OldRanges returns the line ranges of the old file touched by the diff.
+func (d FileDiff) OldRanges() []LineRange {
+	var ranges []LineRange
+	for _, h := range d.Hunks {
+		ranges = append(ranges, lineRange(h.OldStart, h.OldLines))
+	}
+	return ranges
+}
+
+// NewRanges returns the line ranges of the new file touched by the diff.
+func (d FileDiff) NewRanges() []LineRange {
+	var ranges []LineRange
+	for _, h := range d.Hunks {
+		ranges = append(ranges, lineRange(h.NewStart, h.NewLines))
+	}
+	return ranges
+}
+
+// lineRange converts a hunk side into a LineRange. With zero lines, git
+// reports the line after which the change happened.
+func lineRange(start, lines int) LineRange {
+	if lines == 0 {
+		return LineRange{Start: start, End: start + 1, Between: true}
+	}
+	return LineRange{Start: start, End: start + lines - 1}
+}
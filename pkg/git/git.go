@@ -0,0 +1,118 @@
+package git
+
+import (
+	"archive/tar"
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/sunku5494/go-ast-parser/pkg/types"
+)
+
+// run executes a git command in dir and returns its standard output.
+func run(dir string, args ...string) ([]byte, error) {
+	cmd := exec.Command("git", append([]string{"-C", dir}, args...)...)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("git %s: %w: %s", strings.Join(args, " "), err, strings.TrimSpace(stderr.String()))
+	}
+	return out, nil
+}
+
+// Materialize extracts the tree of the given revision for the project
+// directory into a new temporary directory, without touching the working
+// tree. The project may be a subdirectory of the repository. The returned
+// cleanup function removes the temporary directory.
+func Materialize(projectPath, rev string) (string, func(), error) {
+	prefix, err := run(projectPath, "rev-parse", "--show-prefix")
+	if err != nil {
+		return "", nil, err
+	}
+
+	treeish := rev
+	if p := strings.TrimSpace(string(prefix)); p != "" {
+		treeish = rev + ":" + strings.TrimSuffix(p, "/")
+	}
+
+	archive, err := run(projectPath, "archive", "--format=tar", treeish)
+	if err != nil {
+		return "", nil, err
+	}
+
+	dir, err := os.MkdirTemp("", "go-ast-parser-rev-")
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to create temporary directory: %w", err)
+	}
+	cleanup := func() { os.RemoveAll(dir) }
+
+	if err := extractTar(bytes.NewReader(archive), dir); err != nil {
+		cleanup()
+		return "", nil, err
+	}
+	return dir, cleanup, nil
+}
+
+// extractTar writes the regular files and directories of a tar stream below dir.
+func extractTar(r io.Reader, dir string) error {
+	tr := tar.NewReader(r)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("error reading git archive: %w", err)
+		}
+
+		target := filepath.Join(dir, filepath.FromSlash(header.Name))
+		if !strings.HasPrefix(target, filepath.Clean(dir)+string(filepath.Separator)) {
+			return fmt.Errorf("invalid path in git archive: %s", header.Name)
+		}
+
+		switch header.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(target, 0755); err != nil {
+				return err
+			}
+		case tar.TypeReg:
+			if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+				return err
+			}
+			f, err := os.OpenFile(target, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, os.FileMode(header.Mode)&0777)
+			if err != nil {
+				return err
+			}
+			_, err = io.Copy(f, tr)
+			f.Close()
+			if err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// RelocateChunks rewrites chunk IDs, parent IDs and file paths produced from
+// a materialized tree so they refer to the original project directory.
+func RelocateChunks(chunks []types.ChromaDocument, fromDir, toDir string) {
+	for i := range chunks {
+		chunks[i].ID = relocate(chunks[i].ID, fromDir, toDir)
+		for _, key := range []string{"file_path", "parent_id"} {
+			if value, ok := chunks[i].Metadata[key].(string); ok {
+				chunks[i].Metadata[key] = relocate(value, fromDir, toDir)
+			}
+		}
+	}
+}
+
+func relocate(path, fromDir, toDir string) string {
+	if strings.HasPrefix(path, fromDir) {
+		return toDir + strings.TrimPrefix(path, fromDir)
+	}
+	return path
+}
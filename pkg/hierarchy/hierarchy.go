@@ -0,0 +1,281 @@
+package hierarchy
+
+import (
+	"encoding/json"
+	"fmt"
+	"go/types"
+	"io"
+	"sort"
+
+	"golang.org/x/tools/go/packages"
+
+	"github.com/sunku5494/go-ast-parser/pkg/analyzer"
+)
+
+// Edge kinds.
+const (
+	Embeds     = "embeds"
+	Implements = "implements"
+)
+
+// Node is a named type in the hierarchy.
+type Node struct {
+	Name    string `json:"name"`    // Fully qualified type name
+	Kind    string `json:"kind"`    // "struct", "interface" or "type"
+	Package string `json:"package"` // Import path of the declaring package
+}
+
+// Edge relates two types. For Embeds edges IsPointer reports embedding by
+// pointer; for Implements edges it reports that only the pointer type
+// satisfies the interface.
+type Edge struct {
+	From      string `json:"from"`
+	To        string `json:"to"`
+	Kind      string `json:"kind"`
+	IsPointer bool   `json:"is_pointer,omitempty"`
+}
+
+// Graph is the embedding and interface satisfaction graph of a set of packages.
+type Graph struct {
+	Nodes []Node `json:"nodes"`
+	Edges []Edge `json:"edges"`
+}
+
+// Build computes the type hierarchy of the package-level named types of all
+// packages. Embedded types declared outside the packages are added as nodes;
+// interface satisfaction is only checked among the packages' own types.
+//
+// The packages may come from separate packages.Load calls, like the main
+// module and vendor directory loaded by loader.LoadGoProject, whose types
+// are never identical to each other. Satisfaction is therefore decided by
+// matching methods by name and signature string rather than with
+// types.Implements.
+func Build(pkgs []*packages.Package) *Graph {
+	var named []*types.Named
+	nodes := make(map[string]Node)
+	seenPkgs := make(map[string]bool)
+
+	for _, pkg := range pkgs {
+		if pkg.Types == nil || seenPkgs[pkg.PkgPath] {
+			continue
+		}
+		seenPkgs[pkg.PkgPath] = true
+
+		scope := pkg.Types.Scope()
+		for _, name := range scope.Names() {
+			typeName, ok := scope.Lookup(name).(*types.TypeName)
+			if !ok || typeName.IsAlias() {
+				continue
+			}
+			if n, ok := typeName.Type().(*types.Named); ok {
+				named = append(named, n)
+				addNode(nodes, n)
+			}
+		}
+	}
+
+	var edges []Edge
+	for _, n := range named {
+		for _, embedded := range embeddedNamed(n) {
+			addNode(nodes, embedded.named)
+			edges = append(edges, Edge{
+				From:      typeName(n),
+				To:        typeName(embedded.named),
+				Kind:      Embeds,
+				IsPointer: embedded.isPointer,
+			})
+		}
+	}
+
+	// Generic types are skipped as their method sets depend on instantiation
+	var ifaces []*types.Named
+	for _, n := range named {
+		if iface, ok := n.Underlying().(*types.Interface); ok && iface.IsMethodSet() && iface.NumMethods() > 0 && n.TypeParams().Len() == 0 {
+			ifaces = append(ifaces, n)
+		}
+	}
+	ifaceMethods := make([]methodSignatures, len(ifaces))
+	for i, iface := range ifaces {
+		ifaceMethods[i] = interfaceMethods(iface.Underlying().(*types.Interface))
+	}
+	for _, n := range named {
+		if types.IsInterface(n) || n.TypeParams().Len() > 0 {
+			continue
+		}
+		valueMethods := methodSetSignatures(n)
+		pointerMethods := methodSetSignatures(types.NewPointer(n))
+		for i, iface := range ifaces {
+			switch {
+			case valueMethods.satisfy(ifaceMethods[i]):
+				edges = append(edges, Edge{From: typeName(n), To: typeName(iface), Kind: Implements})
+			case pointerMethods.satisfy(ifaceMethods[i]):
+				edges = append(edges, Edge{From: typeName(n), To: typeName(iface), Kind: Implements, IsPointer: true})
+			}
+		}
+	}
+
+	graph := &Graph{Nodes: make([]Node, 0, len(nodes)), Edges: edges}
+	for _, node := range nodes {
+		graph.Nodes = append(graph.Nodes, node)
+	}
+	sort.Slice(graph.Nodes, func(i, j int) bool {
+		return graph.Nodes[i].Name < graph.Nodes[j].Name
+	})
+	sort.SliceStable(graph.Edges, func(i, j int) bool {
+		if graph.Edges[i].From != graph.Edges[j].From {
+			return graph.Edges[i].From < graph.Edges[j].From
+		}
+		return graph.Edges[i].To < graph.Edges[j].To
+	})
+	if graph.Edges == nil {
+		graph.Edges = []Edge{}
+	}
+	return graph
+}
+
+// methodSignatures maps method keys (see methodKey) to signature strings.
+type methodSignatures map[string]string
+
+// interfaceMethods returns the methods of an interface, including embedded ones.
+func interfaceMethods(iface *types.Interface) methodSignatures {
+	methods := make(methodSignatures, iface.NumMethods())
+	for i := 0; i < iface.NumMethods(); i++ {
+		method := iface.Method(i)
+		methods[methodKey(method)] = analyzer.TypeString(method.Type())
+	}
+	return methods
+}
+
+// methodSetSignatures returns the method set of t.
+func methodSetSignatures(t types.Type) methodSignatures {
+	mset := types.NewMethodSet(t)
+	methods := make(methodSignatures, mset.Len())
+	for i := 0; i < mset.Len(); i++ {
+		method := mset.At(i).Obj()
+		methods[methodKey(method)] = analyzer.TypeString(method.Type())
+	}
+	return methods
+}
+
+// satisfy reports whether the methods include every method of an interface.
+func (m methodSignatures) satisfy(iface methodSignatures) bool {
+	for key, signature := range iface {
+		if m[key] != signature {
+			return false
+		}
+	}
+	return true
+}
+
+// methodKey identifies a method by name, qualified by its package path when
+// unexported, as unexported methods only match within their package.
+func methodKey(method types.Object) string {
+	if method.Exported() || method.Pkg() == nil {
+		return method.Name()
+	}
+	return method.Pkg().Path() + "." + method.Name()
+}
+
+// embeddedType is a named type embedded in a struct or interface.
+type embeddedType struct {
+	named     *types.Named
+	isPointer bool
+}
+
+// embeddedNamed returns the named types embedded in n's underlying struct or interface.
+func embeddedNamed(n *types.Named) []embeddedType {
+	var embedded []embeddedType
+	switch u := n.Underlying().(type) {
+	case *types.Struct:
+		for i := 0; i < u.NumFields(); i++ {
+			field := u.Field(i)
+			if !field.Embedded() {
+				continue
+			}
+			t := field.Type()
+			ptr, isPointer := t.(*types.Pointer)
+			if isPointer {
+				t = ptr.Elem()
+			}
+			if en, ok := types.Unalias(t).(*types.Named); ok {
+				embedded = append(embedded, embeddedType{named: en.Origin(), isPointer: isPointer})
+			}
+		}
+	case *types.Interface:
+		for i := 0; i < u.NumEmbeddeds(); i++ {
+			if en, ok := types.Unalias(u.EmbeddedType(i)).(*types.Named); ok {
+				embedded = append(embedded, embeddedType{named: en.Origin()})
+			}
+		}
+	}
+	return embedded
+}
+
+// addNode adds n to nodes unless already present.
+func addNode(nodes map[string]Node, n *types.Named) {
+	name := typeName(n)
+	if _, ok := nodes[name]; ok {
+		return
+	}
+
+	kind := "type"
+	switch n.Underlying().(type) {
+	case *types.Struct:
+		kind = "struct"
+	case *types.Interface:
+		kind = "interface"
+	}
+
+	pkgPath := ""
+	if pkg := n.Obj().Pkg(); pkg != nil {
+		pkgPath = pkg.Path()
+	}
+	nodes[name] = Node{Name: name, Kind: kind, Package: pkgPath}
+}
+
+// typeName returns the qualified name of a named type, without type arguments.
+func typeName(n *types.Named) string {
+	if pkg := n.Obj().Pkg(); pkg != nil {
+		return analyzer.QualifiedName(pkg.Path(), "", n.Obj().Name())
+	}
+	return n.Obj().Name()
+}
+
+// WriteJSON writes the graph as indented JSON.
+func (g *Graph) WriteJSON(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(g)
+}
+
+// WriteDOT writes the graph in Graphviz DOT format. Embedding edges are
+// solid and interface satisfaction edges dashed.
+func (g *Graph) WriteDOT(w io.Writer) error {
+	if _, err := fmt.Fprintln(w, "digraph types {"); err != nil {
+		return err
+	}
+	for _, node := range g.Nodes {
+		shape := "box"
+		if node.Kind == "interface" {
+			shape = "ellipse"
+		}
+		if _, err := fmt.Fprintf(w, "  %q [shape=%s];\n", node.Name, shape); err != nil {
+			return err
+		}
+	}
+	for _, edge := range g.Edges {
+		style := "solid"
+		if edge.Kind == Implements {
+			style = "dashed"
+		}
+		label := edge.Kind
+		if edge.IsPointer {
+			label += " (pointer)"
+		}
+		if _, err := fmt.Fprintf(w, "  %q -> %q [style=%s, label=%q];\n", edge.From, edge.To, style, label); err != nil {
+			return err
+		}
+	}
+	_, err := fmt.Fprintln(w, "}")
+	return err
+}
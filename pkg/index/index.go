@@ -0,0 +1,151 @@
+package index
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/sunku5494/go-ast-parser/pkg/types"
+)
+
+// Index provides symbol-based lookups over a set of extracted code chunks.
+type Index struct {
+	chunks   []types.ChromaDocument
+	byID     map[string]int
+	bySymbol map[string][]int
+	methods  map[string][]int
+	refs     map[string][]int
+}
+
+// New builds an Index from the given chunks.
+func New(chunks []types.ChromaDocument) *Index {
+	ix := &Index{
+		chunks:   chunks,
+		byID:     make(map[string]int),
+		bySymbol: make(map[string][]int),
+		methods:  make(map[string][]int),
+		refs:     make(map[string][]int),
+	}
+
+	for i, chunk := range chunks {
+		ix.byID[chunk.ID] = i
+
+		if name := MetadataString(chunk.Metadata, "qualified_name"); name != "" {
+			ix.bySymbol[name] = append(ix.bySymbol[name], i)
+
+			// Methods are qualified as `pkg.Type.Method`; index them by receiver type
+			if MetadataString(chunk.Metadata, "entity_type") == "method" {
+				if dot := strings.LastIndex(name, "."); dot > 0 {
+					ix.methods[name[:dot]] = append(ix.methods[name[:dot]], i)
+				}
+			}
+		}
+
+		// Multi-name const and var specs define each of their names
+		for _, name := range MetadataStrings(chunk.Metadata, "qualified_names") {
+			ix.bySymbol[name] = append(ix.bySymbol[name], i)
+		}
+
+		for _, symbol := range MetadataStrings(chunk.Metadata, "accessed_symbols") {
+			ix.refs[symbol] = append(ix.refs[symbol], i)
+		}
+	}
+
+	return ix
+}
+
+// Chunks returns all chunks held by the index.
+func (ix *Index) Chunks() []types.ChromaDocument {
+	return ix.chunks
+}
+
+// Get returns the chunk with the given ID.
+func (ix *Index) Get(id string) (types.ChromaDocument, bool) {
+	i, ok := ix.byID[id]
+	if !ok {
+		return types.ChromaDocument{}, false
+	}
+	return ix.chunks[i], true
+}
+
+// Lookup returns the chunks defining the given fully qualified symbol.
+// Method symbols may be written as `pkg.Type.Method`, `pkg.(*Type).Method`
+// or `pkg.(Type).Method`.
+func (ix *Index) Lookup(symbol string) []types.ChromaDocument {
+	return ix.collect(ix.bySymbol[NormalizeSymbol(symbol)])
+}
+
+// References returns the chunks whose accessed symbols include the given symbol.
+func (ix *Index) References(symbol string) []types.ChromaDocument {
+	return ix.collect(ix.refs[NormalizeSymbol(symbol)])
+}
+
+// Methods returns the method chunks declared on the given qualified type name.
+func (ix *Index) Methods(typeSymbol string) []types.ChromaDocument {
+	return ix.collect(ix.methods[NormalizeSymbol(typeSymbol)])
+}
+
+// Symbols returns all qualified symbol names defined in the index, sorted.
+func (ix *Index) Symbols() []string {
+	symbols := make([]string, 0, len(ix.bySymbol))
+	for symbol := range ix.bySymbol {
+		symbols = append(symbols, symbol)
+	}
+	sort.Strings(symbols)
+	return symbols
+}
+
+func (ix *Index) collect(positions []int) []types.ChromaDocument {
+	result := make([]types.ChromaDocument, 0, len(positions))
+	for _, i := range positions {
+		result = append(result, ix.chunks[i])
+	}
+	return result
+}
+
+// NormalizeSymbol converts the supported method spellings to the
+// `importpath.Type.Method` form stored in `qualified_name` metadata.
+func NormalizeSymbol(symbol string) string {
+	symbol = strings.TrimSpace(symbol)
+	symbol = strings.Replace(symbol, "(*", "", 1)
+	symbol = strings.Replace(symbol, "(", "", 1)
+	symbol = strings.Replace(symbol, ")", "", 1)
+	return strings.TrimPrefix(symbol, "*")
+}
+
+// MetadataString returns the string value stored under key, or "" if absent.
+func MetadataString(metadata map[string]interface{}, key string) string {
+	s, _ := metadata[key].(string)
+	return s
+}
+
+// MetadataInt returns the integer value stored under key. Values decoded from
+// JSON are float64, so both representations are accepted.
+func MetadataInt(metadata map[string]interface{}, key string) int {
+	switch v := metadata[key].(type) {
+	case int:
+		return v
+	case float64:
+		return int(v)
+	default:
+		return 0
+	}
+}
+
+// MetadataStrings returns the string list stored under key. Values decoded
+// from JSON are []interface{}, so both representations are accepted.
+func MetadataStrings(metadata map[string]interface{}, key string) []string {
+	switch v := metadata[key].(type) {
+	case []string:
+		return v
+	case []interface{}:
+		result := make([]string, 0, len(v))
+		for _, item := range v {
+			if s, ok := item.(string); ok {
+				result = append(result, s)
+			}
+		}
+		return result
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,159 @@
+package index
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/sunku5494/go-ast-parser/pkg/types"
+)
+
+// Query describes a search over the indexed chunks.
+type Query struct {
+	Text        string // Case-insensitive text matched against names and code
+	EntityType  string // Optional entity_type filter
+	PackagePath string // Optional package_path filter
+	Limit       int    // Maximum number of results; 0 means no limit
+
+	// MinComplexity keeps only functions and methods whose cyclomatic
+	// complexity is at least this value
+	MinComplexity int
+	// SortByComplexity ranks results by cognitive complexity, most complex
+	// first, instead of by relevance
+	SortByComplexity bool
+	// ExcludeGenerated drops chunks from generated files; otherwise they
+	// rank below hand-written code with the same match
+	ExcludeGenerated bool
+}
+
+// SearchResult is a chunk matched by a query together with its relevance score.
+type SearchResult struct {
+	Score int                  `json:"score"`
+	Chunk types.ChromaDocument `json:"chunk"`
+}
+
+// PackageSummary gives an overview of the chunks extracted from one package.
+type PackageSummary struct {
+	Path         string         `json:"path"`
+	Name         string         `json:"name"`
+	IsVendored   bool           `json:"is_vendored"`
+	Files        []string       `json:"files"`
+	EntityCounts map[string]int `json:"entity_counts"`
+}
+
+// Search returns chunks matching the query, best matches first. Matches on the
+// entity name rank above matches on the qualified name, which rank above
+// matches anywhere in the chunk's code. Generated code scores half.
+func (ix *Index) Search(q Query) []SearchResult {
+	text := strings.ToLower(strings.TrimSpace(q.Text))
+
+	var results []SearchResult
+	for _, chunk := range ix.chunks {
+		if q.EntityType != "" && MetadataString(chunk.Metadata, "entity_type") != q.EntityType {
+			continue
+		}
+		if q.PackagePath != "" && MetadataString(chunk.Metadata, "package_path") != q.PackagePath {
+			continue
+		}
+		if q.MinComplexity > 0 && MetadataInt(chunk.Metadata, "cyclomatic_complexity") < q.MinComplexity {
+			continue
+		}
+
+		isGenerated, _ := chunk.Metadata["is_generated"].(bool)
+		if isGenerated && q.ExcludeGenerated {
+			continue
+		}
+
+		score := scoreChunk(chunk, text)
+		if score == 0 {
+			continue
+		}
+		if isGenerated {
+			score = (score + 1) / 2
+		}
+		results = append(results, SearchResult{Score: score, Chunk: chunk})
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		if q.SortByComplexity {
+			return MetadataInt(results[i].Chunk.Metadata, "cognitive_complexity") > MetadataInt(results[j].Chunk.Metadata, "cognitive_complexity")
+		}
+		return results[i].Score > results[j].Score
+	})
+
+	if q.Limit > 0 && len(results) > q.Limit {
+		results = results[:q.Limit]
+	}
+	return results
+}
+
+// scoreChunk rates how well a chunk matches the lower-cased query text.
+func scoreChunk(chunk types.ChromaDocument, text string) int {
+	if text == "" {
+		return 1
+	}
+
+	entityName := strings.ToLower(MetadataString(chunk.Metadata, "entity_name"))
+	switch {
+	case entityName == text:
+		return 100
+	case strings.HasSuffix(entityName, "."+text):
+		return 90
+	case strings.Contains(entityName, text):
+		return 50
+	case strings.Contains(strings.ToLower(MetadataString(chunk.Metadata, "qualified_name")), text):
+		return 30
+	case strings.Contains(strings.ToLower(chunk.Document), text):
+		return 10
+	default:
+		return 0
+	}
+}
+
+// Packages returns a summary of every package in the index, sorted by path.
+func (ix *Index) Packages() []PackageSummary {
+	summaries := make(map[string]*PackageSummary)
+	files := make(map[string]map[string]bool)
+
+	for _, chunk := range ix.chunks {
+		path := MetadataString(chunk.Metadata, "package_path")
+		summary, ok := summaries[path]
+		if !ok {
+			isVendored, _ := chunk.Metadata["is_vendored"].(bool)
+			summary = &PackageSummary{
+				Path:         path,
+				Name:         MetadataString(chunk.Metadata, "package_name"),
+				IsVendored:   isVendored,
+				EntityCounts: make(map[string]int),
+			}
+			summaries[path] = summary
+			files[path] = make(map[string]bool)
+		}
+
+		summary.EntityCounts[MetadataString(chunk.Metadata, "entity_type")]++
+		if file := MetadataString(chunk.Metadata, "file_path"); file != "" && !files[path][file] {
+			files[path][file] = true
+			summary.Files = append(summary.Files, file)
+		}
+	}
+
+	result := make([]PackageSummary, 0, len(summaries))
+	for _, summary := range summaries {
+		sort.Strings(summary.Files)
+		result = append(result, *summary)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Path < result[j].Path
+	})
+	return result
+}
+
+// PackageChunks returns all chunks belonging to the package with the given import path.
+func (ix *Index) PackageChunks(pkgPath string) []types.ChromaDocument {
+	var result []types.ChromaDocument
+	for _, chunk := range ix.chunks {
+		if MetadataString(chunk.Metadata, "package_path") == pkgPath {
+			result = append(result, chunk)
+		}
+	}
+	return result
+}
@@ -0,0 +1,284 @@
+package loader
+
+import (
+	"context"
+	"go/token"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"golang.org/x/tools/go/packages"
+
+	"github.com/sunku5494/go-ast-parser/pkg/stats"
+)
+
+// astBytesPerSourceByte estimates the memory held by the syntax trees and
+// type information of a package per byte of its source files.
+const astBytesPerSourceByte = 60
+
+// listMode lists packages with their files and imports, without parsing them.
+const listMode = packages.NeedName | packages.NeedFiles | packages.NeedImports
+
+// listedPackage is a package found while listing, before it is loaded.
+type listedPackage struct {
+	pkg      *packages.Package
+	vendored bool
+	cost     int64 // Estimated bytes held while the package is loaded
+}
+
+// LoadBatches loads the packages selected by opts like LoadGoProjectContext,
+// but in groups whose estimated memory use stays within opts.MemoryBudgetMB.
+// Groups are ordered so that dependencies come before their importers;
+// packages outside a group are type-checked from export data. fn is called
+// with each group, after which the group's syntax trees and type information
+// are released. A package larger than the budget forms a group of its own.
+//
+// Loading stops at the first error returned by fn, which LoadBatches returns
+// unchanged, or when ctx is cancelled.
+func LoadBatches(ctx context.Context, projectPath string, opts Options, fn func(pkgs []*packages.Package) error) error {
+	logger := opts.logger()
+	vendorDirPath := filepath.Join(projectPath, "vendor")
+	checkVendorDir(opts, vendorDirPath)
+
+	listed, err := listPackages(ctx, projectPath, vendorDirPath, opts)
+	if err != nil {
+		return err
+	}
+	batches := batchPackages(orderPackages(listed), int64(opts.MemoryBudgetMB)<<20)
+	logger.Info("Loading packages in batches", "packages", len(listed), "batches", len(batches), "memory_budget_mb", opts.MemoryBudgetMB)
+
+	for i, batch := range batches {
+		pkgs, err := loadBatch(ctx, projectPath, vendorDirPath, opts, batch)
+		if err != nil {
+			return err
+		}
+		if logger.Enabled(ctx, slog.LevelDebug) {
+			logger.Debug("Loaded batch", "batch", i+1, "packages", len(pkgs), "heap_mb", heapMB())
+		}
+
+		if err := fn(pkgs); err != nil {
+			return err
+		}
+
+		// Nothing of the batch is referenced once fn has returned
+		for _, pkg := range pkgs {
+			pkg.Syntax, pkg.TypesInfo, pkg.Types, pkg.Fset = nil, nil, nil, nil
+		}
+	}
+	return nil
+}
+
+// listPackages lists the main module and vendored packages selected by opts.
+func listPackages(ctx context.Context, projectPath, vendorDirPath string, opts Options) ([]listedPackage, error) {
+	var listed []listedPackage
+	seen := make(map[string]bool)
+	add := func(pkgs []*packages.Package, vendored bool) {
+		for _, pkg := range pkgs {
+			if seen[pkg.ID] || !opts.includePackage(projectPath, pkg) {
+				continue
+			}
+			seen[pkg.ID] = true
+			listed = append(listed, listedPackage{pkg: pkg, vendored: vendored, cost: sourceSize(pkg) * astBytesPerSourceByte})
+		}
+	}
+
+	cfg := opts.packageConfig(ctx, projectPath, token.NewFileSet())
+	cfg.Mode = listMode
+	mainPkgs, err := packages.Load(cfg, opts.patterns()...)
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	if err != nil {
+		opts.logger().Warn("packages.Load listing the main module returned an error; processing available packages", "error", err)
+	}
+	if opts.Tests {
+		mainPkgs = testVariants(mainPkgs)
+	}
+	add(mainPkgs, false)
+
+	if !opts.SkipVendor {
+		if _, err := os.Stat(vendorDirPath); err == nil {
+			vendorPkgs, err := listVendored(ctx, projectPath, vendorDirPath, opts)
+			if err != nil {
+				return nil, err
+			}
+			add(vendorPkgs, true)
+		}
+	}
+	return listed, nil
+}
+
+// orderPackages sorts packages so that each comes after the listed packages
+// it imports, keeping the listing order otherwise.
+func orderPackages(listed []listedPackage) []listedPackage {
+	byID := make(map[string]int, len(listed))
+	for i, lp := range listed {
+		byID[lp.pkg.ID] = i
+	}
+
+	ordered := make([]listedPackage, 0, len(listed))
+	visited := make([]bool, len(listed))
+	var visit func(i int)
+	visit = func(i int) {
+		if visited[i] {
+			return
+		}
+		visited[i] = true
+		for _, imp := range listed[i].pkg.Imports {
+			if j, ok := byID[imp.ID]; ok {
+				visit(j)
+			}
+		}
+		ordered = append(ordered, listed[i])
+	}
+	for i := range listed {
+		visit(i)
+	}
+	return ordered
+}
+
+// batchPackages splits ordered packages into consecutive groups whose
+// estimated cost does not exceed budget bytes. The test variants of a
+// package are never split across groups.
+func batchPackages(ordered []listedPackage, budget int64) [][]listedPackage {
+	var batches [][]listedPackage
+	var current []listedPackage
+	var size int64
+	for _, unit := range batchUnits(ordered) {
+		var cost int64
+		for _, lp := range unit {
+			cost += lp.cost
+		}
+		if len(current) > 0 && size+cost > budget {
+			batches = append(batches, current)
+			current, size = nil, 0
+		}
+		current = append(current, unit...)
+		size += cost
+	}
+	if len(current) > 0 {
+		batches = append(batches, current)
+	}
+	return batches
+}
+
+// batchUnits groups ordered packages into the units loaded together: the
+// test variants of a package, which are loaded by the same pattern, form one
+// unit placed at its last member so that dependencies still come first.
+func batchUnits(ordered []listedPackage) [][]listedPackage {
+	last := make(map[string]int, len(ordered))
+	for i, lp := range ordered {
+		last[testBinary(lp.pkg)] = i
+	}
+
+	var units [][]listedPackage
+	pending := make(map[string][]listedPackage)
+	for i, lp := range ordered {
+		key := testBinary(lp.pkg)
+		pending[key] = append(pending[key], lp)
+		if last[key] == i {
+			units = append(units, pending[key])
+			delete(pending, key)
+		}
+	}
+	return units
+}
+
+// testBinary returns the test binary a test variant belongs to, such as
+// `p.test` for both `p [p.test]` and `p_test [p.test]`, or the ID of other
+// packages.
+func testBinary(pkg *packages.Package) string {
+	if i := strings.Index(pkg.ID, " ["); i >= 0 && strings.HasSuffix(pkg.ID, "]") {
+		return pkg.ID[i+2 : len(pkg.ID)-1]
+	}
+	return pkg.ID
+}
+
+// loadBatch fully loads the packages of a batch, using a file set of its own
+// so that positions are released with the batch.
+func loadBatch(ctx context.Context, projectPath, vendorDirPath string, opts Options, batch []listedPackage) ([]*packages.Package, error) {
+	fset := token.NewFileSet()
+	wanted := make(map[string]bool, len(batch))
+	var mainPatterns, vendorPatterns []string
+	for _, lp := range batch {
+		wanted[lp.pkg.ID] = true
+		if lp.vendored {
+			vendorPatterns = appendUnique(vendorPatterns, lp.pkg.PkgPath)
+		} else {
+			// External test packages are loaded along with the package they test
+			pattern := lp.pkg.PkgPath
+			if testBinary(lp.pkg) != lp.pkg.ID {
+				pattern = strings.TrimSuffix(pattern, "_test")
+			}
+			mainPatterns = appendUnique(mainPatterns, pattern)
+		}
+	}
+
+	var pkgs []*packages.Package
+	load := func(cfg *packages.Config, patterns []string) error {
+		if len(patterns) == 0 {
+			return nil
+		}
+		loaded, err := packages.Load(cfg, patterns...)
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if err != nil {
+			opts.logger().Warn("packages.Load for batch returned an error; processing available packages", "error", err)
+		}
+		for _, pkg := range loaded {
+			if wanted[pkg.ID] {
+				pkgs = append(pkgs, pkg)
+				delete(wanted, pkg.ID)
+			}
+		}
+		return nil
+	}
+
+	if err := load(opts.packageConfig(ctx, projectPath, fset), mainPatterns); err != nil {
+		return nil, err
+	}
+	vendorCfg := opts.packageConfig(ctx, vendorDirPath, fset)
+	vendorCfg.Tests = false // Vendored test files are not vendored
+	if err := load(vendorCfg, vendorPatterns); err != nil {
+		return nil, err
+	}
+
+	for _, lp := range batch {
+		if wanted[lp.pkg.ID] {
+			opts.logger().Warn("Listed package was not loaded", "package", lp.pkg.ID)
+			opts.skip(stats.Skip{Package: lp.pkg.ID, Reason: "package not loaded"})
+		}
+	}
+	return pkgs, nil
+}
+
+// sourceSize returns the total size in bytes of a package's Go files.
+func sourceSize(pkg *packages.Package) int64 {
+	var size int64
+	for _, filePath := range pkg.GoFiles {
+		if info, err := os.Stat(filePath); err == nil {
+			size += info.Size()
+		}
+	}
+	return size
+}
+
+// appendUnique appends s to list unless it is already present.
+func appendUnique(list []string, s string) []string {
+	for _, existing := range list {
+		if existing == s {
+			return list
+		}
+	}
+	return append(list, s)
+}
+
+// heapMB returns the heap memory currently in use, in megabytes.
+func heapMB() uint64 {
+	var m runtime.MemStats
+	runtime.ReadMemStats(&m)
+	return m.HeapInuse >> 20
+}
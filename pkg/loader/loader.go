@@ -1,8 +1,9 @@
 package loader
 
 import (
+	"context"
 	"go/token"
-	"log"
+	"log/slog"
 	"os"
 	"path/filepath"
 
@@ -12,30 +13,49 @@ import (
 // LoadGoProject loads packages from both the main module and vendor directory.
 // It returns a slice of unique packages and handles deduplication.
 func LoadGoProject(projectPath string) ([]*packages.Package, error) {
+	return LoadGoProjectWithOptions(projectPath, Options{})
+}
+
+// LoadGoProjectWithOptions loads packages like LoadGoProject, restricted to
+// the packages selected by opts.
+func LoadGoProjectWithOptions(projectPath string, opts Options) ([]*packages.Package, error) {
+	return LoadGoProjectContext(context.Background(), projectPath, opts)
+}
+
+// LoadGoProjectContext is like LoadGoProjectWithOptions; cancelling ctx
+// stops the underlying build system queries and returns ctx's error.
+func LoadGoProjectContext(ctx context.Context, projectPath string, opts Options) ([]*packages.Package, error) {
 	fset := token.NewFileSet()
+	logger := opts.logger()
 
-	// Check if vendor directory exists
 	vendorDirPath := filepath.Join(projectPath, "vendor")
-	if _, err := os.Stat(vendorDirPath); os.IsNotExist(err) {
-		log.Printf("Warning: Vendor directory does NOT exist at %s. Please run 'go mod vendor' in your project root.", vendorDirPath)
-	} else if err != nil {
-		log.Printf("Error checking vendor directory at %s: %v", vendorDirPath, err)
-	} else {
-		log.Printf("Vendor directory EXISTS at %s.", vendorDirPath)
-	}
+	checkVendorDir(opts, vendorDirPath)
 
 	// List to hold all packages loaded from both main module and vendor
 	var allPkgs []*packages.Package
 	loadedPkgIDs := make(map[string]bool) // To deduplicate packages by ID
 
 	// Step 1: Load packages from the main module
-	log.Printf("Loading packages from main module (%s)...", projectPath)
-	mainModuleCfg := CreatePackageConfig(projectPath, fset)
-	mainPkgs, err := packages.Load(mainModuleCfg, "./...")
+	logger.Info("Loading packages from main module", "dir", projectPath)
+	mainPatterns, err := mainPatterns(ctx, projectPath, opts)
 	if err != nil {
-		log.Printf("Warning: packages.Load for main module returned an error: %v. Attempting to process available packages.", err)
+		return nil, err
+	}
+	var mainPkgs []*packages.Package
+	if len(mainPatterns) > 0 {
+		mainModuleCfg := opts.packageConfig(ctx, projectPath, fset)
+		mainPkgs, err = packages.Load(mainModuleCfg, mainPatterns...)
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		if err != nil {
+			logger.Warn("packages.Load for main module returned an error; processing available packages", "error", err)
+		}
+	}
+	logger.Info("Finished loading main module", "packages", len(mainPkgs))
+	if opts.Tests {
+		mainPkgs = testVariants(mainPkgs)
 	}
-	log.Printf("Finished loading %d packages from main module.", len(mainPkgs))
 
 	for _, pkg := range mainPkgs {
 		if _, ok := loadedPkgIDs[pkg.ID]; !ok {
@@ -48,34 +68,158 @@ func LoadGoProject(projectPath string) ([]*packages.Package, error) {
 	// This ensures all vendored packages are included, even if not directly
 	// referenced by the main module's go.mod (e.g., if it's a transitive dependency
 	// that packages.Load didn't fully resolve in the first pass).
-	log.Printf("Loading packages directly from vendor directory (%s)...", vendorDirPath)
-	vendorCfg := CreatePackageConfig(vendorDirPath, fset)
-	vendorPkgs, err := packages.Load(vendorCfg, "./...")
-	if err != nil {
-		log.Printf("Warning: packages.Load for vendor directory returned an error: %v. Attempting to process available packages.", err)
-	}
-	log.Printf("Finished loading %d packages from vendor directory.", len(vendorPkgs))
+	if opts.SkipVendor {
+		logger.Debug("Skipping vendor directory", "dir", vendorDirPath)
+	} else {
+		logger.Info("Loading packages from vendor directory", "dir", vendorDirPath)
+		vendorPkgs, err := loadVendored(ctx, projectPath, vendorDirPath, opts, fset)
+		if err != nil {
+			return nil, err
+		}
+		logger.Info("Finished loading vendor directory", "packages", len(vendorPkgs))
 
-	for _, pkg := range vendorPkgs {
-		if _, ok := loadedPkgIDs[pkg.ID]; !ok {
-			allPkgs = append(allPkgs, pkg)
-			loadedPkgIDs[pkg.ID] = true
+		for _, pkg := range vendorPkgs {
+			if _, ok := loadedPkgIDs[pkg.ID]; !ok {
+				allPkgs = append(allPkgs, pkg)
+				loadedPkgIDs[pkg.ID] = true
+			}
 		}
 	}
 
-	log.Printf("Total unique packages loaded: %d", len(allPkgs))
+	logger.Info("Loaded packages", "total", len(allPkgs))
 
 	// Diagnostic logging of loaded packages
-	logLoadedPackages(allPkgs)
+	logLoadedPackages(logger, allPkgs)
 
 	return allPkgs, nil
 }
 
+// mainPatterns returns the patterns loading the main module packages. With
+// IncludeFile set, the packages are listed without type information and only
+// those with selected files are returned, by import path.
+func mainPatterns(ctx context.Context, projectPath string, opts Options) ([]string, error) {
+	if opts.IncludeFile == nil {
+		return opts.patterns(), nil
+	}
+
+	cfg := opts.packageConfig(ctx, projectPath, token.NewFileSet())
+	cfg.Mode = listMode
+	cfg.Tests = false // Test variants are loaded along with their package
+	pkgs, err := packages.Load(cfg, opts.patterns()...)
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	if err != nil {
+		opts.logger().Warn("packages.Load listing the main module returned an error; processing available packages", "error", err)
+	}
+
+	var patterns []string
+	for _, pkg := range pkgs {
+		if opts.includePackage(projectPath, pkg) {
+			patterns = appendUnique(patterns, pkg.PkgPath)
+		}
+	}
+	return patterns, nil
+}
+
+// loadVendored fully loads the vendored packages passing the allow and deny
+// lists and IncludeFile. When any of them is set the packages are listed
+// first, without type information, so that excluded packages are never
+// parsed or type-checked.
+func loadVendored(ctx context.Context, projectPath, vendorDirPath string, opts Options, fset *token.FileSet) ([]*packages.Package, error) {
+	patterns := []string{"./..."}
+	if len(opts.VendorAllow) > 0 || len(opts.VendorDeny) > 0 || opts.IncludeFile != nil {
+		listed, err := listVendored(ctx, projectPath, vendorDirPath, opts)
+		if err != nil {
+			return nil, err
+		}
+		patterns = patterns[:0]
+		for _, pkg := range listed {
+			patterns = append(patterns, pkg.PkgPath)
+		}
+		if len(patterns) == 0 {
+			return nil, nil
+		}
+	}
+
+	cfg := opts.packageConfig(ctx, vendorDirPath, fset)
+	cfg.Tests = false // Vendored test files are not vendored
+	pkgs, err := packages.Load(cfg, patterns...)
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	if err != nil {
+		opts.logger().Warn("packages.Load for vendor directory returned an error; processing available packages", "error", err)
+	}
+	return pkgs, nil
+}
+
+// listVendored lists the vendored packages passing the allow and deny lists
+// and IncludeFile with their files and imports only. Only the directories of
+// allow-list entries are listed.
+func listVendored(ctx context.Context, projectPath, vendorDirPath string, opts Options) ([]*packages.Package, error) {
+	patterns := opts.vendorPatterns(vendorDirPath)
+	if len(patterns) == 0 {
+		return nil, nil
+	}
+
+	cfg := opts.packageConfig(ctx, vendorDirPath, token.NewFileSet())
+	cfg.Mode = listMode
+	cfg.Tests = false
+	pkgs, err := packages.Load(cfg, patterns...)
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	if err != nil {
+		opts.logger().Warn("packages.Load listing the vendor directory returned an error; processing available packages", "error", err)
+	}
+
+	var allowed []*packages.Package
+	for _, pkg := range pkgs {
+		if opts.allowVendored(pkg) && opts.includePackage(projectPath, pkg) {
+			allowed = append(allowed, pkg)
+		}
+	}
+	return allowed, nil
+}
+
+// LoadPackages loads only the packages matching the given patterns (import
+// paths or relative directories) from the project, e.g. to refresh a subset
+// of packages after their files changed.
+func LoadPackages(projectPath string, patterns ...string) ([]*packages.Package, error) {
+	return LoadPackagesWithOptions(projectPath, Options{}, patterns...)
+}
+
+// LoadPackagesWithOptions is like LoadPackages, applying the build tags and
+// test settings of opts.
+func LoadPackagesWithOptions(projectPath string, opts Options, patterns ...string) ([]*packages.Package, error) {
+	return LoadPackagesContext(context.Background(), projectPath, opts, patterns...)
+}
+
+// LoadPackagesContext is like LoadPackagesWithOptions, stopping when ctx is
+// cancelled.
+func LoadPackagesContext(ctx context.Context, projectPath string, opts Options, patterns ...string) ([]*packages.Package, error) {
+	cfg := opts.packageConfig(ctx, projectPath, token.NewFileSet())
+	pkgs, err := packages.Load(cfg, patterns...)
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	if err != nil {
+		return nil, err
+	}
+	if opts.Tests {
+		pkgs = testVariants(pkgs)
+	}
+	opts.logger().Info("Loaded packages", "packages", len(pkgs), "patterns", len(patterns))
+	return pkgs, nil
+}
+
 // CreatePackageConfig creates a standardized package configuration for loading.
 func CreatePackageConfig(workDir string, fset *token.FileSet) *packages.Config {
 	return &packages.Config{
-		Mode: packages.NeedName | packages.NeedFiles |
-			packages.NeedTypes | packages.NeedSyntax | packages.NeedTypesInfo,
+		Mode: packages.NeedName | packages.NeedFiles | packages.NeedImports |
+			packages.NeedTypes | packages.NeedSyntax | packages.NeedTypesInfo |
+			packages.NeedEmbedFiles,
 		Fset:  fset,
 		Dir:   workDir,
 		Tests: false,
@@ -83,25 +227,30 @@ func CreatePackageConfig(workDir string, fset *token.FileSet) *packages.Config {
 	}
 }
 
-// logLoadedPackages provides diagnostic output about loaded packages.
-func logLoadedPackages(allPkgs []*packages.Package) {
-	log.Println("--- Listing ALL Loaded Packages (Main Module + Vendor) ---")
+// checkVendorDir warns when the vendor directory is used but does not exist.
+func checkVendorDir(opts Options, vendorDirPath string) {
+	logger := opts.logger()
+	if opts.SkipVendor {
+		// The vendor directory is not used
+	} else if _, err := os.Stat(vendorDirPath); os.IsNotExist(err) {
+		logger.Warn("Vendor directory does not exist; run 'go mod vendor' in the project root to include dependencies", "dir", vendorDirPath)
+	} else if err != nil {
+		logger.Error("Error checking vendor directory", "dir", vendorDirPath, "error", err)
+	} else {
+		logger.Debug("Vendor directory exists", "dir", vendorDirPath)
+	}
+}
+
+// logLoadedPackages lists the loaded packages and their files at debug level.
+func logLoadedPackages(logger *slog.Logger, allPkgs []*packages.Package) {
+	if !logger.Enabled(context.Background(), slog.LevelDebug) {
+		return
+	}
 	for _, pkg := range allPkgs {
-		log.Printf("Package ID: %s", pkg.ID)
-		if len(pkg.GoFiles) > 0 {
-			numFilesToPrint := 3 // Print up to 3 files for brevity
-			if len(pkg.GoFiles) < numFilesToPrint {
-				numFilesToPrint = len(pkg.GoFiles)
-			}
-			for i := 0; i < numFilesToPrint; i++ {
-				log.Printf("  File: %s", pkg.GoFiles[i])
-			}
-			if len(pkg.GoFiles) > numFilesToPrint {
-				log.Printf("  ...and %d more files.", len(pkg.GoFiles)-numFilesToPrint)
-			}
-		} else {
-			log.Println("  No Go files found for this package.")
+		numFilesToPrint := 3 // Print up to 3 files for brevity
+		if len(pkg.GoFiles) < numFilesToPrint {
+			numFilesToPrint = len(pkg.GoFiles)
 		}
+		logger.Debug("Loaded package", "id", pkg.ID, "files", len(pkg.GoFiles), "first_files", pkg.GoFiles[:numFilesToPrint])
 	}
-	log.Println("-----------------------------------------------------")
-} 
\ No newline at end of file
+}
@@ -0,0 +1,59 @@
+package loader
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"golang.org/x/tools/go/packages"
+)
+
+func TestLoadSkipsExcludedPackages(t *testing.T) {
+	t.Setenv("GOFLAGS", "")
+
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "go.mod"), "module example.com/e\n\ngo 1.23\n")
+	writeFile(t, filepath.Join(dir, "a", "a.go"), "package a\n\nfunc A() {}\n")
+	writeFile(t, filepath.Join(dir, "b", "b.go"), "package b\n\nfunc B() {}\n")
+
+	opts := Options{
+		SkipVendor: true,
+		IncludeFile: func(projectPath, filePath string) bool {
+			return !strings.HasPrefix(filePath, filepath.Join(projectPath, "b")+string(filepath.Separator))
+		},
+	}
+	pkgs, err := LoadGoProjectWithOptions(dir, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pkgs) != 1 || pkgs[0].PkgPath != "example.com/e/a" {
+		t.Errorf("full load: got %v, want only example.com/e/a", pkgs)
+	}
+
+	opts.MemoryBudgetMB = 100
+	var batched []string
+	err = LoadBatches(context.Background(), dir, opts, func(pkgs []*packages.Package) error {
+		for _, pkg := range pkgs {
+			batched = append(batched, pkg.PkgPath)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(batched) != 1 || batched[0] != "example.com/e/a" {
+		t.Errorf("batched load: got %v, want only example.com/e/a", batched)
+	}
+}
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
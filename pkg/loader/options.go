@@ -0,0 +1,164 @@
+package loader
+
+import (
+	"context"
+	"go/token"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/tools/go/packages"
+
+	"github.com/sunku5494/go-ast-parser/pkg/stats"
+)
+
+// Options configures which packages are loaded. The zero value loads every
+// package of the main module and of the vendor directory.
+type Options struct {
+	// Patterns selects the main module packages passed to packages.Load;
+	// defaults to "./...".
+	Patterns []string `json:"patterns,omitempty"`
+
+	// VendorAllow, when non-empty, keeps only vendored packages belonging to
+	// one of these module (or package path) prefixes.
+	VendorAllow []string `json:"vendor_allow,omitempty"`
+
+	// VendorDeny drops vendored packages belonging to one of these module (or
+	// package path) prefixes.
+	VendorDeny []string `json:"vendor_deny,omitempty"`
+
+	// BuildTags are passed to the build system as -tags.
+	BuildTags []string `json:"tags,omitempty"`
+
+	// Tests also loads test files. Each package is then represented by its
+	// test variant, which includes the non-test files.
+	Tests bool `json:"tests,omitempty"`
+
+	// SkipVendor loads only the main module, not the vendor directory.
+	SkipVendor bool `json:"skip_vendor,omitempty"`
+
+	// MemoryBudgetMB, when positive, bounds the estimated memory held by
+	// syntax trees and type information: LoadBatches then loads packages in
+	// groups that fit within it.
+	MemoryBudgetMB int `json:"memory_budget_mb,omitempty"`
+
+	// Logger receives progress and diagnostics; defaults to slog.Default().
+	Logger *slog.Logger `json:"-"`
+
+	// OnSkip, when set, is called for every listed package that LoadBatches
+	// could not load.
+	OnSkip func(stats.Skip) `json:"-"`
+
+	// IncludeFile, when set, selects the files of the project at projectPath
+	// that are extracted. Packages are then listed first, and those none of
+	// whose Go files are selected are not loaded.
+	IncludeFile func(projectPath, filePath string) bool `json:"-"`
+}
+
+// logger returns the configured logger.
+func (o Options) logger() *slog.Logger {
+	if o.Logger != nil {
+		return o.Logger
+	}
+	return slog.Default()
+}
+
+// skip reports a skipped package to the OnSkip callback, if any.
+func (o Options) skip(s stats.Skip) {
+	if o.OnSkip != nil {
+		o.OnSkip(s)
+	}
+}
+
+// packageConfig creates the packages.Config for loading from workDir.
+func (o Options) packageConfig(ctx context.Context, workDir string, fset *token.FileSet) *packages.Config {
+	cfg := CreatePackageConfig(workDir, fset)
+	cfg.Context = ctx
+	if len(o.BuildTags) > 0 {
+		cfg.BuildFlags = append(cfg.BuildFlags, "-tags="+strings.Join(o.BuildTags, ","))
+	}
+	cfg.Tests = o.Tests
+	return cfg
+}
+
+// testVariants drops the packages superseded by test variants when tests are
+// loaded: plain packages that have an internal test variant, and the
+// generated test main packages.
+func testVariants(pkgs []*packages.Package) []*packages.Package {
+	hasVariant := make(map[string]bool)
+	for _, pkg := range pkgs {
+		if strings.Contains(pkg.ID, " [") && !strings.HasSuffix(pkg.PkgPath, "_test") {
+			hasVariant[pkg.PkgPath] = true
+		}
+	}
+
+	var result []*packages.Package
+	for _, pkg := range pkgs {
+		if strings.HasSuffix(pkg.PkgPath, ".test") {
+			continue
+		}
+		if !strings.Contains(pkg.ID, " [") && hasVariant[pkg.PkgPath] {
+			continue
+		}
+		result = append(result, pkg)
+	}
+	return result
+}
+
+// patterns returns the configured patterns, or "./..." if there are none.
+func (o Options) patterns() []string {
+	if len(o.Patterns) == 0 {
+		return []string{"./..."}
+	}
+	return o.Patterns
+}
+
+// allowVendored reports whether a vendored package passes the allow and deny lists.
+func (o Options) allowVendored(pkg *packages.Package) bool {
+	if matchesModule(pkg.PkgPath, o.VendorDeny) {
+		return false
+	}
+	return len(o.VendorAllow) == 0 || matchesModule(pkg.PkgPath, o.VendorAllow)
+}
+
+// includePackage reports whether IncludeFile selects one of the package's Go
+// files. Packages without Go files are kept.
+func (o Options) includePackage(projectPath string, pkg *packages.Package) bool {
+	if o.IncludeFile == nil || len(pkg.GoFiles) == 0 {
+		return true
+	}
+	for _, filePath := range pkg.GoFiles {
+		if o.IncludeFile(projectPath, filePath) {
+			return true
+		}
+	}
+	return false
+}
+
+// vendorPatterns returns the patterns listing the vendored packages that may
+// pass the allow list, relative to the vendor directory, which mirrors import
+// paths. Allow-list entries without a vendored directory are dropped.
+func (o Options) vendorPatterns(vendorDirPath string) []string {
+	if len(o.VendorAllow) == 0 {
+		return []string{"./..."}
+	}
+	var patterns []string
+	for _, path := range o.VendorAllow {
+		path = strings.TrimSuffix(path, "/")
+		if info, err := os.Stat(filepath.Join(vendorDirPath, filepath.FromSlash(path))); err == nil && info.IsDir() {
+			patterns = append(patterns, "./"+path+"/...")
+		}
+	}
+	return patterns
+}
+
+// matchesModule reports whether pkgPath is one of the paths or inside one of them.
+func matchesModule(pkgPath string, paths []string) bool {
+	for _, path := range paths {
+		if pkgPath == path || strings.HasPrefix(pkgPath, strings.TrimSuffix(path, "/")+"/") {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,92 @@
+package mcp
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/sunku5494/go-ast-parser/pkg/analyzer"
+	"github.com/sunku5494/go-ast-parser/pkg/hierarchy"
+	"github.com/sunku5494/go-ast-parser/pkg/index"
+)
+
+// relatedType is a type related to the subject of get_type_hierarchy.
+type relatedType struct {
+	Name      string `json:"name"`
+	IsPointer bool   `json:"is_pointer,omitempty"`
+}
+
+// relatedTypes returns the types related to the named type, keyed by
+// relation. The server's hierarchy graph is used when set; otherwise only
+// embeddings are derived from the chunks' embedded_types metadata.
+func (s *Server) relatedTypes(name string) map[string][]relatedType {
+	if s.Hierarchy != nil {
+		return graphRelations(s.Hierarchy, name)
+	}
+
+	related := map[string][]relatedType{
+		"embeds":      {},
+		"embedded_by": {},
+	}
+	for _, chunk := range s.ix.Chunks() {
+		owner := index.MetadataString(chunk.Metadata, "qualified_name")
+		for _, embedded := range embeddedTypes(chunk.Metadata["embedded_types"]) {
+			switch {
+			case owner == name:
+				related["embeds"] = append(related["embeds"], embedded)
+			case embedded.Name == name:
+				related["embedded_by"] = append(related["embedded_by"], relatedType{Name: owner, IsPointer: embedded.IsPointer})
+			}
+		}
+	}
+	return related
+}
+
+// graphRelations collects the edges of graph touching the named type.
+func graphRelations(graph *hierarchy.Graph, name string) map[string][]relatedType {
+	related := map[string][]relatedType{
+		"embeds":         {},
+		"embedded_by":    {},
+		"implements":     {},
+		"implemented_by": {},
+	}
+	for _, edge := range graph.Edges {
+		outgoing, incoming := "embeds", "embedded_by"
+		if edge.Kind == hierarchy.Implements {
+			outgoing, incoming = "implements", "implemented_by"
+		}
+		switch name {
+		case edge.From:
+			related[outgoing] = append(related[outgoing], relatedType{Name: edge.To, IsPointer: edge.IsPointer})
+		case edge.To:
+			related[incoming] = append(related[incoming], relatedType{Name: edge.From, IsPointer: edge.IsPointer})
+		}
+	}
+	return related
+}
+
+// embeddedTypes decodes embedded_types metadata, which holds
+// analyzer.EmbeddedType values or their JSON form, dropping type arguments
+// so that names match qualified names.
+func embeddedTypes(value interface{}) []relatedType {
+	if value == nil {
+		return nil
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil
+	}
+	var embedded []analyzer.EmbeddedType
+	if err := json.Unmarshal(data, &embedded); err != nil {
+		return nil
+	}
+
+	related := make([]relatedType, 0, len(embedded))
+	for _, e := range embedded {
+		name := e.Type
+		if i := strings.Index(name, "["); i >= 0 {
+			name = name[:i]
+		}
+		related = append(related, relatedType{Name: name, IsPointer: e.IsPointer})
+	}
+	return related
+}
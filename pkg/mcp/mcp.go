@@ -0,0 +1,150 @@
+package mcp
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+
+	"github.com/sunku5494/go-ast-parser/pkg/hierarchy"
+	"github.com/sunku5494/go-ast-parser/pkg/index"
+)
+
+// ProtocolVersion is the Model Context Protocol revision implemented by Server.
+const ProtocolVersion = "2024-11-05"
+
+// JSON-RPC 2.0 error codes used by the server.
+const (
+	codeParseError     = -32700
+	codeMethodNotFound = -32601
+	codeInvalidParams  = -32602
+)
+
+// Server answers Model Context Protocol requests over newline-delimited
+// JSON-RPC 2.0, exposing code navigation tools backed by an Index.
+type Server struct {
+	// Hierarchy, when set, provides the embedding and interface satisfaction
+	// edges reported by get_type_hierarchy. Without it only embeddings,
+	// taken from chunk metadata, are reported.
+	Hierarchy *hierarchy.Graph
+
+	ix      *index.Index
+	name    string
+	version string
+}
+
+type request struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id,omitempty"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params,omitempty"`
+}
+
+type response struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Result  interface{}     `json:"result,omitempty"`
+	Error   *rpcError       `json:"error,omitempty"`
+}
+
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// New creates a Server backed by the given index. The name and version are
+// reported to clients during initialization.
+func New(ix *index.Index, name, version string) *Server {
+	return &Server{ix: ix, name: name, version: version}
+}
+
+// Serve reads requests from r and writes responses to w until r is exhausted.
+func (s *Server) Serve(r io.Reader, w io.Writer) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	encoder := json.NewEncoder(w)
+
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+
+		var req request
+		if err := json.Unmarshal(line, &req); err != nil {
+			if err := encoder.Encode(response{JSONRPC: "2.0", ID: json.RawMessage("null"), Error: &rpcError{Code: codeParseError, Message: err.Error()}}); err != nil {
+				return err
+			}
+			continue
+		}
+
+		// Notifications carry no ID and must not be answered
+		if len(req.ID) == 0 {
+			continue
+		}
+
+		resp := response{JSONRPC: "2.0", ID: req.ID}
+		resp.Result, resp.Error = s.handle(req)
+		if err := encoder.Encode(resp); err != nil {
+			return err
+		}
+	}
+
+	return scanner.Err()
+}
+
+// handle dispatches a single request to its method implementation.
+func (s *Server) handle(req request) (interface{}, *rpcError) {
+	switch req.Method {
+	case "initialize":
+		return map[string]interface{}{
+			"protocolVersion": ProtocolVersion,
+			"capabilities":    map[string]interface{}{"tools": map[string]interface{}{}},
+			"serverInfo":      map[string]string{"name": s.name, "version": s.version},
+		}, nil
+	case "ping":
+		return map[string]interface{}{}, nil
+	case "tools/list":
+		return map[string]interface{}{"tools": toolDefinitions}, nil
+	case "tools/call":
+		var params struct {
+			Name      string                 `json:"name"`
+			Arguments map[string]interface{} `json:"arguments"`
+		}
+		if err := json.Unmarshal(req.Params, &params); err != nil {
+			return nil, &rpcError{Code: codeInvalidParams, Message: err.Error()}
+		}
+		return s.callTool(params.Name, params.Arguments), nil
+	default:
+		return nil, &rpcError{Code: codeMethodNotFound, Message: "method not found: " + req.Method}
+	}
+}
+
+// callTool runs a tool and wraps its outcome in an MCP tool result. Tool
+// failures are reported in the result rather than as protocol errors.
+func (s *Server) callTool(name string, args map[string]interface{}) map[string]interface{} {
+	tool, ok := tools[name]
+	if !ok {
+		return toolResult(fmt.Sprintf("unknown tool: %s", name), true)
+	}
+
+	result, err := tool(s, args)
+	if err != nil {
+		return toolResult(err.Error(), true)
+	}
+
+	text, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		slog.Error("Error encoding tool result", "tool", name, "error", err)
+		return toolResult(err.Error(), true)
+	}
+	return toolResult(string(text), false)
+}
+
+func toolResult(text string, isError bool) map[string]interface{} {
+	return map[string]interface{}{
+		"content": []map[string]string{{"type": "text", "text": text}},
+		"isError": isError,
+	}
+}
@@ -0,0 +1,198 @@
+package mcp
+
+import (
+	"fmt"
+
+	"github.com/sunku5494/go-ast-parser/pkg/index"
+	"github.com/sunku5494/go-ast-parser/pkg/types"
+)
+
+// toolFunc implements a single MCP tool over the server's index.
+type toolFunc func(s *Server, args map[string]interface{}) (interface{}, error)
+
+// tools maps tool names to their implementations.
+var tools = map[string]toolFunc{
+	"search_code":        searchCode,
+	"get_symbol":         getSymbol,
+	"find_references":    findReferences,
+	"list_package":       listPackage,
+	"get_type_hierarchy": getTypeHierarchy,
+}
+
+// toolDefinitions describes the tools advertised by tools/list.
+var toolDefinitions = []map[string]interface{}{
+	{
+		"name":        "search_code",
+		"description": "Search Go code chunks by name or content. Results are ranked with exact name matches first.",
+		"inputSchema": objectSchema(map[string]interface{}{
+			"query":             stringProperty("Text to search for in entity names and code"),
+			"entity_type":       stringProperty("Optional entity type filter, e.g. function, method, struct"),
+			"package":           stringProperty("Optional import path filter"),
+			"limit":             map[string]interface{}{"type": "integer", "description": "Maximum number of results (default 20)"},
+			"min_complexity":    map[string]interface{}{"type": "integer", "description": "Only return functions with at least this cyclomatic complexity"},
+			"sort":              stringProperty("Set to 'complexity' to rank the most complex functions first"),
+			"exclude_generated": map[string]interface{}{"type": "boolean", "description": "Leave out chunks from generated files"},
+		}, "query"),
+	},
+	{
+		"name":        "get_symbol",
+		"description": "Get the definition of a fully qualified Go symbol such as github.com/foo/bar.Client.Do.",
+		"inputSchema": objectSchema(map[string]interface{}{
+			"symbol": stringProperty("Fully qualified symbol name"),
+		}, "symbol"),
+	},
+	{
+		"name":        "find_references",
+		"description": "List code chunks that access a fully qualified symbol: a package-level entity, or a method or field given as pkg.Type.Member.",
+		"inputSchema": objectSchema(map[string]interface{}{
+			"symbol": stringProperty("Fully qualified symbol name"),
+		}, "symbol"),
+	},
+	{
+		"name":        "list_package",
+		"description": "List the symbols defined in a package, or summarize all packages when no package is given.",
+		"inputSchema": objectSchema(map[string]interface{}{
+			"package": stringProperty("Import path of the package"),
+		}),
+	},
+	{
+		"name":        "get_type_hierarchy",
+		"description": "Get a type definition with its declared methods, the types it embeds and is embedded by, and the interfaces it implements or, for interfaces, is implemented by.",
+		"inputSchema": objectSchema(map[string]interface{}{
+			"symbol": stringProperty("Fully qualified type name"),
+		}, "symbol"),
+	},
+}
+
+// symbolEntry is the compact chunk description returned by listing tools.
+type symbolEntry struct {
+	ID            string `json:"id"`
+	QualifiedName string `json:"qualified_name"`
+	EntityType    string `json:"entity_type"`
+	Location      string `json:"location"`
+}
+
+func searchCode(s *Server, args map[string]interface{}) (interface{}, error) {
+	query, err := requiredString(args, "query")
+	if err != nil {
+		return nil, err
+	}
+
+	limit := 20
+	if v, ok := args["limit"].(float64); ok && v > 0 {
+		limit = int(v)
+	}
+	minComplexity := 0
+	if v, ok := args["min_complexity"].(float64); ok && v > 0 {
+		minComplexity = int(v)
+	}
+	excludeGenerated, _ := args["exclude_generated"].(bool)
+
+	return s.ix.Search(index.Query{
+		Text:        query,
+		EntityType:  optionalString(args, "entity_type"),
+		PackagePath: optionalString(args, "package"),
+		Limit:       limit,
+
+		MinComplexity:    minComplexity,
+		SortByComplexity: optionalString(args, "sort") == "complexity",
+		ExcludeGenerated: excludeGenerated,
+	}), nil
+}
+
+func getSymbol(s *Server, args map[string]interface{}) (interface{}, error) {
+	symbol, err := requiredString(args, "symbol")
+	if err != nil {
+		return nil, err
+	}
+
+	definitions := s.ix.Lookup(symbol)
+	if len(definitions) == 0 {
+		return nil, fmt.Errorf("symbol not found: %s", symbol)
+	}
+	return definitions, nil
+}
+
+func findReferences(s *Server, args map[string]interface{}) (interface{}, error) {
+	symbol, err := requiredString(args, "symbol")
+	if err != nil {
+		return nil, err
+	}
+	return entries(s.ix.References(symbol)), nil
+}
+
+func listPackage(s *Server, args map[string]interface{}) (interface{}, error) {
+	pkgPath := optionalString(args, "package")
+	if pkgPath == "" {
+		return s.ix.Packages(), nil
+	}
+
+	chunks := s.ix.PackageChunks(pkgPath)
+	if len(chunks) == 0 {
+		return nil, fmt.Errorf("package not found: %s", pkgPath)
+	}
+	return entries(chunks), nil
+}
+
+func getTypeHierarchy(s *Server, args map[string]interface{}) (interface{}, error) {
+	symbol, err := requiredString(args, "symbol")
+	if err != nil {
+		return nil, err
+	}
+
+	definitions := s.ix.Lookup(symbol)
+	if len(definitions) == 0 {
+		return nil, fmt.Errorf("type not found: %s", symbol)
+	}
+
+	result := map[string]interface{}{
+		"type":    definitions[0],
+		"methods": entries(s.ix.Methods(symbol)),
+	}
+	for key, related := range s.relatedTypes(index.NormalizeSymbol(symbol)) {
+		result[key] = related
+	}
+	return result, nil
+}
+
+func entries(chunks []types.ChromaDocument) []symbolEntry {
+	result := make([]symbolEntry, 0, len(chunks))
+	for _, chunk := range chunks {
+		result = append(result, symbolEntry{
+			ID:            chunk.ID,
+			QualifiedName: index.MetadataString(chunk.Metadata, "qualified_name"),
+			EntityType:    index.MetadataString(chunk.Metadata, "entity_type"),
+			Location: fmt.Sprintf("%s:%d-%d", index.MetadataString(chunk.Metadata, "file_path"),
+				index.MetadataInt(chunk.Metadata, "start_line"), index.MetadataInt(chunk.Metadata, "end_line")),
+		})
+	}
+	return result
+}
+
+func requiredString(args map[string]interface{}, key string) (string, error) {
+	s := optionalString(args, key)
+	if s == "" {
+		return "", fmt.Errorf("missing required argument: %s", key)
+	}
+	return s, nil
+}
+
+func optionalString(args map[string]interface{}, key string) string {
+	s, _ := args[key].(string)
+	return s
+}
+
+func objectSchema(properties map[string]interface{}, required ...string) map[string]interface{} {
+	schema := map[string]interface{}{
+		"type":       "object",
+		"properties": properties,
+	}
+	if len(required) > 0 {
+		schema["required"] = required
+	}
+	return schema
+}
+
+func stringProperty(description string) map[string]interface{} {
+	return map[string]interface{}{"type": "string", "description": description}
+}
@@ -4,12 +4,27 @@ import (
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
+	"log/slog"
 
 	"github.com/sunku5494/go-ast-parser/pkg/types"
 )
 
 // WriteChunksToJSON writes the code chunks to a JSON file with pretty formatting.
 func WriteChunksToJSON(chunks []types.ChromaDocument, filename string) error {
+	if err := writeJSONFile(chunks, filename); err != nil {
+		return err
+	}
+
+	slog.Debug("Wrote code chunks", "chunks", len(chunks), "file", filename)
+	return nil
+}
+
+// writeJSONFile marshals the chunks with indentation and writes them to
+// filename. No chunks are written as an empty array, as by ChunkWriter.
+func writeJSONFile(chunks []types.ChromaDocument, filename string) error {
+	if chunks == nil {
+		chunks = []types.ChromaDocument{}
+	}
 	jsonData, err := json.MarshalIndent(chunks, "", "  ")
 	if err != nil {
 		return fmt.Errorf("error marshaling chunks to JSON: %w", err)
@@ -20,6 +35,20 @@ func WriteChunksToJSON(chunks []types.ChromaDocument, filename string) error {
 		return fmt.Errorf("error writing JSON to file: %w", err)
 	}
 
-	fmt.Printf("Successfully extracted %d code chunks to %s\n", len(chunks), filename)
 	return nil
-} 
\ No newline at end of file
+}
+
+// ReadChunksFromJSON reads code chunks previously written by WriteChunksToJSON.
+func ReadChunksFromJSON(filename string) ([]types.ChromaDocument, error) {
+	jsonData, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("error reading JSON file: %w", err)
+	}
+
+	var chunks []types.ChromaDocument
+	if err := json.Unmarshal(jsonData, &chunks); err != nil {
+		return nil, fmt.Errorf("error unmarshaling chunks from JSON: %w", err)
+	}
+
+	return chunks, nil
+}
@@ -0,0 +1,45 @@
+package output
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestWritersWriteEmptyArrayForNoChunks(t *testing.T) {
+	dir := t.TempDir()
+
+	whole := filepath.Join(dir, "whole.json")
+	if err := WriteChunksToJSON(nil, whole); err != nil {
+		t.Fatal(err)
+	}
+
+	streamed := filepath.Join(dir, "streamed.json")
+	w, err := CreateChunkWriter(streamed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Write(nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, filename := range []string{whole, streamed} {
+		data, err := os.ReadFile(filename)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(data) != "[]" {
+			t.Errorf("%s: got %q, want %q", filepath.Base(filename), data, "[]")
+		}
+		chunks, err := ReadChunksFromJSON(filename)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(chunks) != 0 {
+			t.Errorf("%s: read %d chunks, want none", filepath.Base(filename), len(chunks))
+		}
+	}
+}
@@ -0,0 +1,114 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+
+	"github.com/sunku5494/go-ast-parser/pkg/types"
+)
+
+// Sink receives incremental chunk updates.
+type Sink interface {
+	// Upsert adds new chunks or replaces chunks with the same ID.
+	Upsert(chunks []types.ChromaDocument) error
+	// Delete removes the chunks with the given IDs.
+	Delete(ids []string) error
+}
+
+// JSONFileSink keeps the full chunk set in memory and rewrites a JSON file,
+// in the same format as WriteChunksToJSON, after every update.
+type JSONFileSink struct {
+	mu       sync.Mutex
+	filename string
+	chunks   map[string]types.ChromaDocument
+}
+
+// NewJSONFileSink creates a sink writing to filename.
+func NewJSONFileSink(filename string) *JSONFileSink {
+	return &JSONFileSink{filename: filename, chunks: make(map[string]types.ChromaDocument)}
+}
+
+// Upsert implements Sink.
+func (s *JSONFileSink) Upsert(chunks []types.ChromaDocument) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, chunk := range chunks {
+		s.chunks[chunk.ID] = chunk
+	}
+	return s.flush()
+}
+
+// Delete implements Sink.
+func (s *JSONFileSink) Delete(ids []string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, id := range ids {
+		delete(s.chunks, id)
+	}
+	return s.flush()
+}
+
+// flush writes all chunks sorted by ID so the file is stable across updates.
+func (s *JSONFileSink) flush() error {
+	ids := make([]string, 0, len(s.chunks))
+	for id := range s.chunks {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	chunks := make([]types.ChromaDocument, 0, len(ids))
+	for _, id := range ids {
+		chunks = append(chunks, s.chunks[id])
+	}
+	return writeJSONFile(chunks, s.filename)
+}
+
+// ChunkEvent is a single update emitted by EventSink.
+type ChunkEvent struct {
+	Op    string                `json:"op"` // "upsert" or "delete"
+	ID    string                `json:"id"`
+	Chunk *types.ChromaDocument `json:"chunk,omitempty"`
+}
+
+// EventSink writes every update as a line of JSON to a writer, which lets
+// other processes consume changes as a stream.
+type EventSink struct {
+	mu      sync.Mutex
+	encoder *json.Encoder
+}
+
+// NewEventSink creates a sink writing JSON lines to w.
+func NewEventSink(w io.Writer) *EventSink {
+	return &EventSink{encoder: json.NewEncoder(w)}
+}
+
+// Upsert implements Sink.
+func (s *EventSink) Upsert(chunks []types.ChromaDocument) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i := range chunks {
+		if err := s.encoder.Encode(ChunkEvent{Op: "upsert", ID: chunks[i].ID, Chunk: &chunks[i]}); err != nil {
+			return fmt.Errorf("error writing chunk event: %w", err)
+		}
+	}
+	return nil
+}
+
+// Delete implements Sink.
+func (s *EventSink) Delete(ids []string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, id := range ids {
+		if err := s.encoder.Encode(ChunkEvent{Op: "delete", ID: id}); err != nil {
+			return fmt.Errorf("error writing chunk event: %w", err)
+		}
+	}
+	return nil
+}
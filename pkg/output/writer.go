@@ -0,0 +1,84 @@
+package output
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+
+	"github.com/sunku5494/go-ast-parser/pkg/types"
+)
+
+// ChunkWriter streams chunks to a JSON file in the same format as
+// WriteChunksToJSON, so that they need not all be held in memory.
+type ChunkWriter struct {
+	file   *os.File
+	w      *bufio.Writer
+	count  int
+	closed bool
+}
+
+// CreateChunkWriter creates or truncates filename and starts the JSON array.
+func CreateChunkWriter(filename string) (*ChunkWriter, error) {
+	file, err := os.Create(filename)
+	if err != nil {
+		return nil, fmt.Errorf("error writing JSON to file: %w", err)
+	}
+	w := &ChunkWriter{file: file, w: bufio.NewWriter(file)}
+	if _, err := w.w.WriteString("["); err != nil {
+		file.Close()
+		return nil, fmt.Errorf("error writing JSON to file: %w", err)
+	}
+	return w, nil
+}
+
+// Write appends chunks to the file.
+func (w *ChunkWriter) Write(chunks []types.ChromaDocument) error {
+	for _, chunk := range chunks {
+		jsonData, err := json.MarshalIndent(chunk, "  ", "  ")
+		if err != nil {
+			return fmt.Errorf("error marshaling chunks to JSON: %w", err)
+		}
+
+		separator := ",\n  "
+		if w.count == 0 {
+			separator = "\n  "
+		}
+		w.w.WriteString(separator)
+		if _, err := w.w.Write(jsonData); err != nil {
+			return fmt.Errorf("error writing JSON to file: %w", err)
+		}
+		w.count++
+	}
+	return nil
+}
+
+// Count returns the number of chunks written so far.
+func (w *ChunkWriter) Count() int {
+	return w.count
+}
+
+// Close ends the JSON array and closes the file.
+func (w *ChunkWriter) Close() error {
+	if w.closed {
+		return nil
+	}
+	w.closed = true
+
+	end := "]"
+	if w.count > 0 {
+		end = "\n]"
+	}
+	w.w.WriteString(end)
+	err := w.w.Flush()
+	if closeErr := w.file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("error writing JSON to file: %w", err)
+	}
+
+	slog.Debug("Wrote code chunks", "chunks", w.count, "file", w.file.Name())
+	return nil
+}
@@ -0,0 +1,28 @@
+package parser
+
+import (
+	"context"
+
+	"golang.org/x/tools/go/packages"
+
+	"github.com/sunku5494/go-ast-parser/pkg/types"
+)
+
+// Batches extracts code chunks from a project whose packages are loaded in
+// several groups, such as by loader.LoadBatches. Files that are not tied to a
+// single package, like go.mod, are emitted with the first group only.
+type Batches struct {
+	projectPath string
+	opts        Options
+}
+
+// NewBatches creates a Batches extracting chunks as configured by opts.
+func NewBatches(projectPath string, opts Options) *Batches {
+	opts.seenFiles = make(map[string]bool)
+	return &Batches{projectPath: projectPath, opts: opts}
+}
+
+// Parse extracts the chunks of one group of packages like ParsePackagesContext.
+func (b *Batches) Parse(ctx context.Context, pkgs []*packages.Package) ([]types.ChromaDocument, error) {
+	return ParsePackagesContext(ctx, pkgs, b.projectPath, b.opts)
+}
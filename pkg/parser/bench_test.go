@@ -0,0 +1,94 @@
+package parser
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/sunku5494/go-ast-parser/pkg/loader"
+)
+
+// BenchmarkParsePackages measures chunk extraction over a generated fixture
+// tree of BENCH_PACKAGES packages of BENCH_FILES files, each with BENCH_SPECS
+// type, const and var specs and as many methods. Loading is not measured.
+// The defaults are smaller than those of scripts/bench.sh to keep a single
+// iteration short.
+func BenchmarkParsePackages(b *testing.B) {
+	benchPackages := benchShape(b, "BENCH_PACKAGES", 10)
+	benchFiles := benchShape(b, "BENCH_FILES", 10)
+	benchSpecs := benchShape(b, "BENCH_SPECS", 50)
+
+	dir := b.TempDir()
+	writeBenchFixture(b, dir, benchPackages, benchFiles, benchSpecs)
+
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	pkgs, err := loader.LoadGoProjectWithOptions(dir, loader.Options{SkipVendor: true, Logger: logger})
+	if err != nil {
+		b.Fatalf("loading fixture: %v", err)
+	}
+	if len(pkgs) != benchPackages {
+		b.Fatalf("loaded %d packages, want %d", len(pkgs), benchPackages)
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		chunks, err := ParsePackagesWithOptions(pkgs, dir, Options{Logger: logger})
+		if err != nil {
+			b.Fatal(err)
+		}
+		if len(chunks) == 0 {
+			b.Fatal("no chunks extracted")
+		}
+	}
+}
+
+// benchShape returns the positive integer set in the environment variable
+// name, or def when it is unset.
+func benchShape(b *testing.B, name string, def int) int {
+	value := os.Getenv(name)
+	if value == "" {
+		return def
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil || n <= 0 {
+		b.Fatalf("%s=%q is not a positive integer", name, value)
+	}
+	return n
+}
+
+// writeBenchFixture writes the module of BenchmarkParsePackages to dir.
+func writeBenchFixture(b *testing.B, dir string, benchPackages, benchFiles, benchSpecs int) {
+	b.Helper()
+	write := func(name, content string) {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			b.Fatal(err)
+		}
+	}
+
+	write("go.mod", "module example.com/bench\n\ngo 1.23\n")
+	for p := 0; p < benchPackages; p++ {
+		pkg := fmt.Sprintf("pkg%d", p)
+		if err := os.Mkdir(filepath.Join(dir, pkg), 0755); err != nil {
+			b.Fatal(err)
+		}
+		for f := 0; f < benchFiles; f++ {
+			var src strings.Builder
+			fmt.Fprintf(&src, "package %s\n\nimport \"strings\"\n\n", pkg)
+			for i := 0; i < benchSpecs; i++ {
+				n := fmt.Sprintf("%d_%d", f, i)
+				fmt.Fprintf(&src, "// T%s is a generated struct.\ntype T%s struct {\n\tName  string\n\tCount int\n}\n\n", n, n)
+				fmt.Fprintf(&src, "// C%s is a generated constant.\nconst C%s = %d\n\n", n, n, i)
+				fmt.Fprintf(&src, "// V%s is a generated variable.\nvar V%s = strings.Repeat(\"x\", C%s)\n\n", n, n, n)
+				fmt.Fprintf(&src, "// Describe%s describes a T%s.\nfunc (t T%s) Describe%s() string {\n", n, n, n, n)
+				fmt.Fprintf(&src, "\tif t.Count > C%s {\n\t\treturn strings.ToUpper(t.Name)\n\t}\n\treturn t.Name + V%s\n}\n\n", n, n)
+			}
+			write(filepath.Join(pkg, fmt.Sprintf("file%d.go", f)), src.String())
+		}
+	}
+}
@@ -0,0 +1,157 @@
+package parser
+
+import (
+	"fmt"
+	"go/ast"
+
+	"golang.org/x/tools/go/packages"
+
+	"github.com/sunku5494/go-ast-parser/pkg/analyzer"
+	"github.com/sunku5494/go-ast-parser/pkg/types"
+)
+
+// closureChunks extracts the function literals of a declaration spanning at
+// least opts.ClosureMinLines lines as child chunks of the declaration's chunks.
+// Literals are named after their enclosing declaration and numbered in source
+// order: `New.func1`, `T.M.func1` for a method whatever its receiver, and
+// `V.func1` in the initializer of a package-level var V. A literal nested in
+// another one is numbered within it, e.g. `New.func1.1`. The names resemble
+// but do not always match the ones in compiler output and stack traces.
+func closureChunks(decl ast.Decl, pkg *packages.Package, parents []types.ChromaDocument, fileContent string, opts *Options) []types.ChromaDocument {
+	if opts.ClosureMinLines <= 0 || len(parents) == 0 {
+		return nil
+	}
+
+	var chunks []types.ChromaDocument
+	var visit func(node ast.Node, parent types.ChromaDocument, parentName string, nested bool)
+	visit = func(node ast.Node, parent types.ChromaDocument, parentName string, nested bool) {
+		count := 0
+		ast.Inspect(node, func(n ast.Node) bool {
+			lit, ok := n.(*ast.FuncLit)
+			if !ok {
+				return true
+			}
+			count++
+			name := fmt.Sprintf("%s.func%d", parentName, count)
+			if nested {
+				name = fmt.Sprintf("%s.%d", parentName, count)
+			}
+
+			if chunk := closureChunk(lit, pkg, parent, name, parentName, fileContent, opts); chunk != nil {
+				chunks = append(chunks, *chunk)
+				visit(lit.Body, *chunk, name, true)
+			} else {
+				visit(lit.Body, parent, name, true)
+			}
+			return false
+		})
+	}
+
+	switch d := decl.(type) {
+	case *ast.FuncDecl:
+		if d.Body == nil {
+			return nil
+		}
+		name := d.Name.Name
+		if d.Recv != nil && len(d.Recv.List) > 0 {
+			name = analyzer.ReceiverTypeName(d.Recv.List[0].Type) + "." + name
+		}
+		visit(d.Body, parents[0], name, false)
+	case *ast.GenDecl:
+		for _, spec := range d.Specs {
+			valueSpec, ok := spec.(*ast.ValueSpec)
+			if !ok {
+				continue
+			}
+			line := pkg.Fset.Position(valueSpec.Pos()).Line
+			for i, value := range valueSpec.Values {
+				// Each value initializes the name at the same index, whose chunk
+				// is the parent when multi-name specs are split
+				name := ""
+				if len(valueSpec.Values) == len(valueSpec.Names) {
+					name = valueSpec.Names[i].Name
+				}
+				parent, ok := enclosingChunk(parents, line, name)
+				if !ok {
+					continue
+				}
+				visit(value, parent, parent.Metadata["entity_name"].(string), false)
+			}
+		}
+	}
+	return chunks
+}
+
+// closureChunk builds the chunk of a function literal, or returns nil if it
+// is shorter than the configured threshold.
+func closureChunk(lit *ast.FuncLit, pkg *packages.Package, parent types.ChromaDocument, name, enclosing, fileContent string, opts *Options) *types.ChromaDocument {
+	startPos := pkg.Fset.Position(lit.Pos())
+	endPos := pkg.Fset.Position(lit.End())
+	if endPos.Line-startPos.Line+1 < opts.ClosureMinLines {
+		return nil
+	}
+	if startPos.Offset < 0 || endPos.Offset > len(fileContent) || startPos.Offset > endPos.Offset {
+		return nil
+	}
+
+	metadata := map[string]interface{}{
+		"file_path":          parent.Metadata["file_path"],
+		"package_name":       parent.Metadata["package_name"],
+		"package_path":       parent.Metadata["package_path"],
+		"is_vendored":        parent.Metadata["is_vendored"],
+		"entity_type":        "closure",
+		"entity_name":        name,
+		"qualified_name":     analyzer.QualifiedName(pkg.PkgPath, "", name),
+		"parent_id":          parent.ID,
+		"enclosing_function": enclosing,
+		"start_line":         startPos.Line,
+		"end_line":           endPos.Line,
+		"is_exported":        false,
+		"is_deprecated":      false,
+	}
+	// File-level and declaration metadata applies to the literal as well
+	for _, key := range []string{"build_constraint", "is_generated", "generator", "directives"} {
+		if value, ok := parent.Metadata[key]; ok {
+			metadata[key] = value
+		}
+	}
+
+	stopAnalyze := opts.Timings.Track("analyze")
+	metadata["accessed_symbols"] = analyzer.ExtractAccessedSymbols(lit, pkg.TypesInfo)
+	metadata["captured_variables"] = analyzer.CapturedVariables(lit, pkg.TypesInfo)
+	if t := pkg.TypesInfo.TypeOf(lit); t != nil {
+		metadata["signature"] = analyzer.TypeString(t)
+	}
+	setMetrics(metadata, analyzer.ComputeFunctionMetrics(pkg.Fset, lit, "", lit.Type, lit.Body))
+	stopAnalyze()
+
+	code := opts.transform(fileContent[startPos.Offset:endPos.Offset], lit, pkg.TypesInfo)
+
+	return &types.ChromaDocument{
+		ID:       fmt.Sprintf("%s:%d-%d-%s", startPos.Filename, startPos.Line, endPos.Line, name),
+		Document: code,
+		Metadata: metadata,
+	}
+}
+
+// enclosingChunk returns the chunk whose line range contains line, preferring
+// the one named name, since the chunks split from a multi-name spec share the
+// spec's range. Otherwise the first containing chunk is returned.
+func enclosingChunk(chunks []types.ChromaDocument, line int, name string) (types.ChromaDocument, bool) {
+	var found types.ChromaDocument
+	ok := false
+	for _, chunk := range chunks {
+		start, _ := chunk.Metadata["start_line"].(int)
+		end, _ := chunk.Metadata["end_line"].(int)
+		if start > line || line > end {
+			continue
+		}
+		if name != "" && chunk.Metadata["entity_name"] == name {
+			return chunk, true
+		}
+		if !ok {
+			found, ok = chunk, true
+		}
+	}
+	return found, ok
+}
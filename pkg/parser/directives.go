@@ -0,0 +1,102 @@
+package parser
+
+import (
+	"fmt"
+	"go/ast"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/tools/go/packages"
+
+	"github.com/sunku5494/go-ast-parser/pkg/analyzer"
+	"github.com/sunku5494/go-ast-parser/pkg/types"
+)
+
+// lineComments indexes the comment groups of a file by the line they start on.
+func lineComments(file *ast.File, pkg *packages.Package) map[int][]*ast.CommentGroup {
+	byLine := make(map[int][]*ast.CommentGroup)
+	for _, group := range file.Comments {
+		line := pkg.Fset.Position(group.Pos()).Line
+		byLine[line] = append(byLine[line], group)
+	}
+	return byLine
+}
+
+// declDirectives returns the directives of a declaration: those in its doc
+// comment and those trailing its first line, such as `//nolint`. comments
+// holds the file's comment groups indexed by lineComments.
+func declDirectives(comments map[int][]*ast.CommentGroup, pkg *packages.Package, node ast.Node, doc *ast.CommentGroup) []analyzer.Directive {
+	groups := []*ast.CommentGroup{doc}
+	for _, group := range comments[pkg.Fset.Position(node.Pos()).Line] {
+		if group != doc && group.Pos() > node.Pos() {
+			groups = append(groups, group)
+		}
+	}
+	return analyzer.Directives(pkg.Fset, groups...)
+}
+
+// setDirectives appends directives to the `directives` metadata of a chunk.
+func setDirectives(metadata map[string]interface{}, directives []analyzer.Directive) {
+	if len(directives) == 0 {
+		return
+	}
+	existing, _ := metadata["directives"].([]analyzer.Directive)
+	metadata["directives"] = append(append([]analyzer.Directive(nil), existing...), directives...)
+}
+
+// buildConstraint returns the expression of a file's //go:build line, if any.
+func buildConstraint(file *ast.File, pkg *packages.Package) string {
+	for _, group := range file.Comments {
+		if group.Pos() >= file.Package {
+			break
+		}
+		for _, directive := range analyzer.Directives(pkg.Fset, group) {
+			if directive.Name == "go:build" {
+				return directive.Args
+			}
+		}
+	}
+	return ""
+}
+
+// generateChunk builds a file-level chunk summarizing the //go:generate
+// commands of a file, or returns nil if it has none.
+func generateChunk(file *ast.File, pkg *packages.Package, filePath string, baseMetadata map[string]interface{}) *types.ChromaDocument {
+	var commands []analyzer.Directive
+	for _, directive := range analyzer.Directives(pkg.Fset, file.Comments...) {
+		if directive.Name == "go:generate" {
+			commands = append(commands, directive)
+		}
+	}
+	if len(commands) == 0 {
+		return nil
+	}
+
+	var doc strings.Builder
+	var generators []string
+	for _, command := range commands {
+		fmt.Fprintf(&doc, "//go:generate %s\n", command.Args)
+		if fields := strings.Fields(command.Args); len(fields) > 0 {
+			generators = append(generators, fields[0])
+		}
+	}
+
+	metadata := make(map[string]interface{})
+	for k, v := range baseMetadata {
+		metadata[k] = v
+	}
+	metadata["entity_type"] = "go_generate"
+	metadata["entity_name"] = filepath.Base(filePath) + ":generate"
+	metadata["directives"] = commands
+	metadata["generators"] = generators
+	metadata["start_line"] = commands[0].Line
+	metadata["end_line"] = commands[len(commands)-1].Line
+	metadata["is_exported"] = false
+	metadata["is_deprecated"] = false
+
+	return &types.ChromaDocument{
+		ID:       fmt.Sprintf("%s:%d-%d-go:generate", filePath, commands[0].Line, commands[len(commands)-1].Line),
+		Document: doc.String(),
+		Metadata: metadata,
+	}
+}
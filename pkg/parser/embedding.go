@@ -0,0 +1,29 @@
+package parser
+
+import (
+	"go/ast"
+
+	"golang.org/x/tools/go/packages"
+
+	"github.com/sunku5494/go-ast-parser/pkg/analyzer"
+)
+
+// addEmbedding stores the types embedded in a struct or interface type and,
+// for structs, the fields and methods promoted from them.
+func addEmbedding(typeSpec *ast.TypeSpec, pkg *packages.Package, metadata map[string]interface{}) {
+	obj := pkg.TypesInfo.Defs[typeSpec.Name]
+	if obj == nil || typeSpec.Assign.IsValid() {
+		return
+	}
+
+	t := obj.Type()
+	if embedded := analyzer.EmbeddedTypes(t); len(embedded) > 0 {
+		metadata["embedded_types"] = embedded
+	}
+	if fields := analyzer.PromotedFields(t); len(fields) > 0 {
+		metadata["promoted_fields"] = fields
+	}
+	if methods := analyzer.PromotedMethods(t); len(methods) > 0 {
+		metadata["promoted_methods"] = methods
+	}
+}
@@ -0,0 +1,126 @@
+package parser
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/printer"
+	"go/token"
+	gotypes "go/types"
+	"strings"
+
+	"golang.org/x/tools/go/packages"
+
+	"github.com/sunku5494/go-ast-parser/pkg/analyzer"
+	"github.com/sunku5494/go-ast-parser/pkg/types"
+)
+
+// addConstValues stores the computed value and type of each constant
+// declared by a const spec, which covers implicit repetitions in iota blocks.
+func addConstValues(valueSpec *ast.ValueSpec, pkg *packages.Package, metadata map[string]interface{}) {
+	var values []map[string]interface{}
+	for _, name := range valueSpec.Names {
+		c, ok := pkg.TypesInfo.Defs[name].(*gotypes.Const)
+		if !ok {
+			continue
+		}
+		values = append(values, map[string]interface{}{
+			"name":  name.Name,
+			"type":  gotypes.TypeString(c.Type(), nil),
+			"value": analyzer.ConstantValue(c),
+		})
+	}
+
+	if len(values) == 1 {
+		metadata["const_type"] = values[0]["type"]
+		metadata["const_value"] = values[0]["value"]
+	} else if len(values) > 1 {
+		metadata["const_values"] = values
+	}
+}
+
+// enumChunk builds an "enum" chunk for a const block whose constants all have
+// the same named type declared in the package, such as an iota sequence. The
+// chunk aggregates the type, its named values and its String method, if any.
+// It is qualified as `pkg.Type.enum` to keep it apart from the type's chunk.
+func enumChunk(genDecl *ast.GenDecl, pkg *packages.Package, baseMetadata map[string]interface{}, filePath string, startPos, endPos token.Position) *types.ChromaDocument {
+	var enumType *gotypes.Named
+	var consts []*gotypes.Const
+	for _, spec := range genDecl.Specs {
+		valueSpec, ok := spec.(*ast.ValueSpec)
+		if !ok {
+			return nil
+		}
+		for _, name := range valueSpec.Names {
+			c, ok := pkg.TypesInfo.Defs[name].(*gotypes.Const)
+			if !ok || name.Name == "_" {
+				continue
+			}
+			named, ok := c.Type().(*gotypes.Named)
+			if !ok || named.Obj().Pkg() != pkg.Types || (enumType != nil && named != enumType) {
+				return nil
+			}
+			enumType = named
+			consts = append(consts, c)
+		}
+	}
+	if len(consts) < 2 {
+		return nil
+	}
+
+	typeName := enumType.Obj().Name()
+	var doc strings.Builder
+	fmt.Fprintf(&doc, "type %s %s\n\nconst (\n", typeName, gotypes.TypeString(enumType.Underlying(), nil))
+	var values []map[string]interface{}
+	for _, c := range consts {
+		value := analyzer.ConstantValue(c)
+		fmt.Fprintf(&doc, "\t%s %s = %s\n", c.Name(), typeName, value)
+		values = append(values, map[string]interface{}{"name": c.Name(), "value": value})
+	}
+	doc.WriteString(")\n")
+
+	stringMethod := findMethod(pkg, typeName, "String")
+	if stringMethod != nil {
+		var b bytes.Buffer
+		if err := printer.Fprint(&b, pkg.Fset, stringMethod); err == nil {
+			doc.WriteString("\n" + b.String() + "\n")
+		}
+	}
+
+	metadata := make(map[string]interface{})
+	for k, v := range baseMetadata {
+		metadata[k] = v
+	}
+	metadata["entity_type"] = "enum"
+	metadata["entity_name"] = typeName
+	metadata["qualified_name"] = analyzer.QualifiedName(pkg.PkgPath, "", typeName+".enum")
+	metadata["enum_type"] = gotypes.TypeString(enumType, nil)
+	metadata["enum_values"] = values
+	metadata["has_string_method"] = stringMethod != nil
+	metadata["start_line"] = startPos.Line
+	metadata["end_line"] = endPos.Line
+	setVisibility(metadata, enumType.Obj().Exported(), genDecl.Doc)
+
+	return &types.ChromaDocument{
+		ID:       fmt.Sprintf("%s:%d-%d-%s.enum", filePath, startPos.Line, endPos.Line, typeName),
+		Document: doc.String(),
+		Metadata: metadata,
+	}
+}
+
+// findMethod returns the declaration of the named method of a package-level
+// type, searching all files of the package.
+func findMethod(pkg *packages.Package, typeName, methodName string) *ast.FuncDecl {
+	for _, file := range pkg.Syntax {
+		for _, decl := range file.Decls {
+			funcDecl, ok := decl.(*ast.FuncDecl)
+			if !ok || funcDecl.Recv == nil || len(funcDecl.Recv.List) == 0 || funcDecl.Name.Name != methodName {
+				continue
+			}
+			if analyzer.ReceiverTypeName(funcDecl.Recv.List[0].Type) == typeName {
+				return funcDecl
+			}
+		}
+	}
+	return nil
+}
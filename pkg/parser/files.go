@@ -0,0 +1,240 @@
+package parser
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"golang.org/x/tools/go/packages"
+
+	"github.com/sunku5494/go-ast-parser/pkg/analyzer"
+	"github.com/sunku5494/go-ast-parser/pkg/stats"
+	"github.com/sunku5494/go-ast-parser/pkg/types"
+)
+
+// maxFileChunkSize is the largest non-Go file turned into a chunk.
+const maxFileChunkSize = 1 << 20
+
+// fileChunks builds chunks for the non-Go files of a project: its go.mod and
+// go.work files, the files embedded with //go:embed, the packages' other and
+// ignored source files (assembly, C, build-constrained Go files) and .proto
+// files in package directories. Each file produces one chunk.
+func fileChunks(allPkgs []*packages.Package, projectPath, absVendorPath string, opts *Options) []types.ChromaDocument {
+	var chunks []types.ChromaDocument
+	seen := opts.seenFiles
+	if seen == nil {
+		seen = make(map[string]bool)
+	}
+
+	add := func(filePath, entityType string, pkg *packages.Package, extra map[string]interface{}) {
+		if seen[filePath] || !opts.Filter.IncludesFile(projectPath, filePath) {
+			return
+		}
+		seen[filePath] = true
+
+		chunk, reason := fileChunk(filePath, entityType, pkg, absVendorPath, extra)
+		if reason != "" {
+			packageID := ""
+			if pkg != nil {
+				packageID = pkg.ID
+			}
+			opts.logger().Debug("Skipping file", "file", filePath, "reason", reason)
+			opts.skip(stats.Skip{Package: packageID, File: filePath, Reason: reason})
+			return
+		}
+		if opts.Filter.includeChunk(*chunk) {
+			chunks = append(chunks, *chunk)
+		}
+	}
+
+	for _, name := range []string{"go.mod", "go.work"} {
+		filePath, err := filepath.Abs(filepath.Join(projectPath, name))
+		if err != nil {
+			continue
+		}
+		if _, err := os.Stat(filePath); err == nil {
+			add(filePath, strings.Replace(name, ".", "_", 1), nil, nil)
+		}
+	}
+
+	for _, pkg := range allPkgs {
+		embeddedBy := embedDirectives(pkg)
+		for _, filePath := range pkg.EmbedFiles {
+			var vars []string
+			for variable, patterns := range embeddedBy {
+				if matchesEmbedPattern(pkg.Dir, filePath, patterns) {
+					vars = append(vars, variable)
+				}
+			}
+			add(filePath, "embedded_file", pkg, map[string]interface{}{"embedded_by": sortedStrings(vars)})
+		}
+
+		for _, filePath := range pkg.OtherFiles {
+			entityType := "other_file"
+			if strings.EqualFold(filepath.Ext(filePath), ".s") {
+				entityType = "assembly"
+			}
+			add(filePath, entityType, pkg, nil)
+		}
+
+		for _, filePath := range pkg.IgnoredFiles {
+			add(filePath, "ignored_file", pkg, nil)
+		}
+
+		if pkg.Dir != "" {
+			protoFiles, _ := filepath.Glob(filepath.Join(pkg.Dir, "*.proto"))
+			for _, filePath := range protoFiles {
+				add(filePath, "proto", pkg, nil)
+			}
+		}
+	}
+
+	return chunks
+}
+
+// fileChunk reads a file into a chunk. When the file cannot be used it
+// returns the reason instead.
+func fileChunk(filePath, entityType string, pkg *packages.Package, absVendorPath string, extra map[string]interface{}) (*types.ChromaDocument, string) {
+	info, err := os.Stat(filePath)
+	if err != nil {
+		return nil, "file read error"
+	}
+	if info.Size() > maxFileChunkSize {
+		return nil, "file too large"
+	}
+
+	content, err := ioutil.ReadFile(filePath)
+	if err != nil {
+		return nil, "file read error"
+	}
+	if bytes.IndexByte(content, 0) >= 0 {
+		return nil, "binary file"
+	}
+
+	lines := bytes.Count(content, []byte("\n"))
+	if len(content) > 0 && content[len(content)-1] != '\n' {
+		lines++
+	}
+
+	name := filepath.Base(filePath)
+	metadata := map[string]interface{}{
+		"file_path":     filePath,
+		"is_vendored":   strings.HasPrefix(filePath, absVendorPath+string(filepath.Separator)),
+		"entity_type":   entityType,
+		"entity_name":   name,
+		"start_line":    1,
+		"end_line":      lines,
+		"is_exported":   false,
+		"is_deprecated": false,
+	}
+	if pkg != nil {
+		metadata["package_name"] = pkg.Name
+		metadata["package_path"] = pkg.PkgPath
+	}
+	if entityType == "go_mod" || entityType == "go_work" {
+		for k, v := range parseModFile(string(content)) {
+			metadata[k] = v
+		}
+
+		// Module files belong to no package. They are attributed to the module
+		// they declare, and go.work, which declares none, to its file name
+		modulePath, _ := metadata["module_path"].(string)
+		if modulePath == "" {
+			modulePath = name
+		}
+		metadata["package_path"] = modulePath
+		metadata["package_name"] = name
+	}
+	for k, v := range extra {
+		metadata[k] = v
+	}
+
+	return &types.ChromaDocument{
+		ID:       fmt.Sprintf("%s:%d-%d-%s", filePath, 1, lines, name),
+		Document: string(content),
+		Metadata: metadata,
+	}, ""
+}
+
+// embedDirectives returns the //go:embed patterns of each package-level
+// variable of a package, keyed by qualified variable name.
+func embedDirectives(pkg *packages.Package) map[string][]string {
+	directives := make(map[string][]string)
+	for _, file := range pkg.Syntax {
+		for _, decl := range file.Decls {
+			genDecl, ok := decl.(*ast.GenDecl)
+			if !ok {
+				continue
+			}
+			for _, spec := range genDecl.Specs {
+				valueSpec, ok := spec.(*ast.ValueSpec)
+				if !ok {
+					continue
+				}
+				doc := valueSpec.Doc
+				if doc == nil && len(genDecl.Specs) == 1 {
+					doc = genDecl.Doc
+				}
+				patterns := embedPatterns(doc)
+				if len(patterns) == 0 {
+					continue
+				}
+				for _, name := range valueSpec.Names {
+					qualified := analyzer.QualifiedName(pkg.PkgPath, "", name.Name)
+					directives[qualified] = append(directives[qualified], patterns...)
+				}
+			}
+		}
+	}
+	return directives
+}
+
+// embedPatterns returns the patterns of the //go:embed directives in a comment group.
+func embedPatterns(doc *ast.CommentGroup) []string {
+	if doc == nil {
+		return nil
+	}
+	var patterns []string
+	for _, comment := range doc.List {
+		if args, ok := strings.CutPrefix(comment.Text, "//go:embed "); ok {
+			for _, pattern := range strings.Fields(args) {
+				patterns = append(patterns, strings.Trim(pattern, "\"`"))
+			}
+		}
+	}
+	return patterns
+}
+
+// matchesEmbedPattern reports whether an embedded file is selected by one of
+// the patterns, either directly or through a matched parent directory.
+func matchesEmbedPattern(pkgDir, filePath string, patterns []string) bool {
+	rel, err := filepath.Rel(pkgDir, filePath)
+	if err != nil {
+		return false
+	}
+	rel = filepath.ToSlash(rel)
+
+	for _, pattern := range patterns {
+		pattern = strings.TrimPrefix(pattern, "all:")
+		for candidate := rel; candidate != "." && candidate != ""; candidate = filepath.ToSlash(filepath.Dir(candidate)) {
+			if matched, _ := filepath.Match(pattern, candidate); matched {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// sortedStrings returns s sorted, or nil if it is empty.
+func sortedStrings(s []string) []string {
+	if len(s) == 0 {
+		return nil
+	}
+	sort.Strings(s)
+	return s
+}
@@ -0,0 +1,102 @@
+package parser
+
+import (
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/sunku5494/go-ast-parser/pkg/types"
+)
+
+// Filter selects which files and chunks are extracted. Path globs are matched
+// against slash-separated paths relative to the project root and may use `**`
+// to match any number of directories, e.g. `internal/**` or `**/*_mock.go`.
+type Filter struct {
+	IncludePaths       []string `json:"include_paths,omitempty"`        // Only files matching one of these globs
+	ExcludePaths       []string `json:"exclude_paths,omitempty"`        // Skip files matching one of these globs
+	EntityTypes        []string `json:"entity_types,omitempty"`         // Only chunks of these entity types
+	ExcludeEntityTypes []string `json:"exclude_entity_types,omitempty"` // Skip chunks of these entity types
+	MinChunkSize       int      `json:"min_chunk_size,omitempty"`       // Skip chunks with less code, in bytes
+}
+
+// HasPathGlobs reports whether the filter selects files by path.
+func (f Filter) HasPathGlobs() bool {
+	return len(f.IncludePaths) > 0 || len(f.ExcludePaths) > 0
+}
+
+// IncludesFile reports whether a file of the project at projectPath passes
+// the path globs. Set as loader.Options.IncludeFile, it keeps packages whose
+// files are all excluded from being loaded at all.
+func (f Filter) IncludesFile(projectPath, filePath string) bool {
+	if !f.HasPathGlobs() {
+		return true
+	}
+
+	rel := filePath
+	if absProjectPath, err := filepath.Abs(projectPath); err == nil {
+		if r, err := filepath.Rel(absProjectPath, filePath); err == nil {
+			rel = r
+		}
+	}
+	rel = filepath.ToSlash(rel)
+
+	if matchAnyGlob(f.ExcludePaths, rel) {
+		return false
+	}
+	return len(f.IncludePaths) == 0 || matchAnyGlob(f.IncludePaths, rel)
+}
+
+// includeChunk reports whether a chunk passes the entity type and size filters.
+func (f Filter) includeChunk(chunk types.ChromaDocument) bool {
+	entityType, _ := chunk.Metadata["entity_type"].(string)
+	if len(f.EntityTypes) > 0 && !contains(f.EntityTypes, entityType) {
+		return false
+	}
+	if contains(f.ExcludeEntityTypes, entityType) {
+		return false
+	}
+	return len(chunk.Document) >= f.MinChunkSize
+}
+
+// matchAnyGlob reports whether name matches one of the globs.
+func matchAnyGlob(globs []string, name string) bool {
+	for _, glob := range globs {
+		if matchGlob(strings.Split(glob, "/"), strings.Split(name, "/")) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchGlob matches path segments against glob segments, where a `**`
+// segment matches zero or more path segments.
+func matchGlob(glob, name []string) bool {
+	for len(glob) > 0 {
+		if glob[0] == "**" {
+			for i := 0; i <= len(name); i++ {
+				if matchGlob(glob[1:], name[i:]) {
+					return true
+				}
+			}
+			return false
+		}
+		if len(name) == 0 {
+			return false
+		}
+		if matched, err := path.Match(glob[0], name[0]); err != nil || !matched {
+			return false
+		}
+		glob, name = glob[1:], name[1:]
+	}
+	return len(name) == 0
+}
+
+// contains reports whether values contains s.
+func contains(values []string, s string) bool {
+	for _, v := range values {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,103 @@
+package parser
+
+import (
+	"strings"
+)
+
+// parseModFile extracts the directives of a go.mod or go.work file into
+// metadata: the module path, go and toolchain versions, requirements,
+// replacements and, for go.work, the used directories. Unknown directives
+// are ignored.
+func parseModFile(content string) map[string]interface{} {
+	metadata := make(map[string]interface{})
+	var requires, replaces []map[string]interface{}
+	var uses, excludes []string
+
+	block := ""
+	for _, line := range strings.Split(content, "\n") {
+		indirect := false
+		if i := strings.Index(line, "//"); i >= 0 {
+			indirect = strings.TrimSpace(line[i+2:]) == "indirect"
+			line = line[:i]
+		}
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+
+		verb := block
+		switch {
+		case block != "" && fields[0] == ")":
+			block = ""
+			continue
+		case block == "" && len(fields) == 2 && fields[1] == "(":
+			block = fields[0]
+			continue
+		case block == "":
+			verb, fields = fields[0], fields[1:]
+		}
+
+		switch verb {
+		case "module":
+			if len(fields) > 0 {
+				metadata["module_path"] = strings.Trim(fields[0], `"`)
+			}
+		case "go":
+			if len(fields) > 0 {
+				metadata["go_version"] = fields[0]
+			}
+		case "toolchain":
+			if len(fields) > 0 {
+				metadata["toolchain"] = fields[0]
+			}
+		case "require":
+			if len(fields) >= 2 {
+				requires = append(requires, map[string]interface{}{
+					"path":     fields[0],
+					"version":  fields[1],
+					"indirect": indirect,
+				})
+			}
+		case "replace":
+			if arrow := indexOf(fields, "=>"); arrow > 0 && arrow < len(fields)-1 {
+				replace := map[string]interface{}{"old": fields[0], "new": fields[arrow+1]}
+				if arrow+2 < len(fields) {
+					replace["new_version"] = fields[arrow+2]
+				}
+				replaces = append(replaces, replace)
+			}
+		case "exclude":
+			if len(fields) >= 2 {
+				excludes = append(excludes, fields[0]+"@"+fields[1])
+			}
+		case "use":
+			if len(fields) > 0 {
+				uses = append(uses, fields[0])
+			}
+		}
+	}
+
+	if len(requires) > 0 {
+		metadata["requires"] = requires
+	}
+	if len(replaces) > 0 {
+		metadata["replaces"] = replaces
+	}
+	if len(excludes) > 0 {
+		metadata["excludes"] = excludes
+	}
+	if len(uses) > 0 {
+		metadata["uses"] = uses
+	}
+	return metadata
+}
+
+// indexOf returns the index of s in fields, or -1.
+func indexOf(fields []string, s string) int {
+	for i, field := range fields {
+		if field == s {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,46 @@
+package parser
+
+import (
+	"go/ast"
+
+	"golang.org/x/tools/go/packages"
+
+	"github.com/sunku5494/go-ast-parser/pkg/analyzer"
+)
+
+// addFunctionMetrics stores complexity metrics for a function declaration,
+// and separately for each function literal inside it, in the metadata.
+func addFunctionMetrics(funcDecl *ast.FuncDecl, pkg *packages.Package, metadata map[string]interface{}) {
+	metrics := analyzer.ComputeFunctionMetrics(pkg.Fset, funcDecl, funcDecl.Name.Name, funcDecl.Type, funcDecl.Body)
+	setMetrics(metadata, metrics)
+
+	if funcDecl.Body == nil {
+		return
+	}
+
+	var closures []map[string]interface{}
+	ast.Inspect(funcDecl.Body, func(n ast.Node) bool {
+		if lit, ok := n.(*ast.FuncLit); ok {
+			closure := map[string]interface{}{
+				"start_line": pkg.Fset.Position(lit.Pos()).Line,
+				"end_line":   pkg.Fset.Position(lit.End()).Line,
+			}
+			setMetrics(closure, analyzer.ComputeFunctionMetrics(pkg.Fset, lit, "", lit.Type, lit.Body))
+			closures = append(closures, closure)
+		}
+		return true
+	})
+	if len(closures) > 0 {
+		metadata["closures"] = closures
+	}
+}
+
+// setMetrics writes function metrics as numeric metadata fields.
+func setMetrics(metadata map[string]interface{}, metrics analyzer.FunctionMetrics) {
+	metadata["cyclomatic_complexity"] = metrics.CyclomaticComplexity
+	metadata["cognitive_complexity"] = metrics.CognitiveComplexity
+	metadata["max_nesting_depth"] = metrics.MaxNestingDepth
+	metadata["statement_count"] = metrics.StatementCount
+	metadata["parameter_count"] = metrics.ParameterCount
+	metadata["lines_of_code"] = metrics.LinesOfCode
+}
@@ -0,0 +1,87 @@
+package parser
+
+import (
+	"fmt"
+	"go/ast"
+
+	"golang.org/x/tools/go/packages"
+
+	"github.com/sunku5494/go-ast-parser/pkg/analyzer"
+	"github.com/sunku5494/go-ast-parser/pkg/types"
+)
+
+// addValueNames stores the names declared by a multi-name const or var spec,
+// with their qualified names and signatures, so that each name can be looked
+// up individually.
+func addValueNames(valueSpec *ast.ValueSpec, pkg *packages.Package, metadata map[string]interface{}) {
+	if len(valueSpec.Names) < 2 {
+		return
+	}
+
+	var names, qualifiedNames, signatures []string
+	for _, name := range valueSpec.Names {
+		names = append(names, name.Name)
+		qualifiedNames = append(qualifiedNames, analyzer.QualifiedName(pkg.PkgPath, "", name.Name))
+		signature := ""
+		if obj := pkg.TypesInfo.Defs[name]; obj != nil {
+			signature = analyzer.ObjectSignature(obj)
+		}
+		signatures = append(signatures, signature)
+	}
+	metadata["names"] = names
+	metadata["qualified_names"] = qualifiedNames
+	metadata["signatures"] = signatures
+}
+
+// splitValueChunk turns the chunk of a multi-name const or var spec into one
+// chunk per declared name, all sharing the spec's source text. Blank names
+// are dropped.
+func splitValueChunk(chunk types.ChromaDocument, valueSpec *ast.ValueSpec, pkg *packages.Package) []types.ChromaDocument {
+	names, _ := chunk.Metadata["names"].([]string)
+	if len(names) < 2 {
+		return []types.ChromaDocument{chunk}
+	}
+	qualifiedNames := chunk.Metadata["qualified_names"].([]string)
+	signatures := chunk.Metadata["signatures"].([]string)
+	constValues, _ := chunk.Metadata["const_values"].([]map[string]interface{})
+
+	startLine, _ := chunk.Metadata["start_line"].(int)
+	endLine, _ := chunk.Metadata["end_line"].(int)
+	filePath, _ := chunk.Metadata["file_path"].(string)
+
+	var chunks []types.ChromaDocument
+	for i, name := range names {
+		if name == "_" {
+			continue
+		}
+
+		metadata := make(map[string]interface{})
+		for k, v := range chunk.Metadata {
+			metadata[k] = v
+		}
+		delete(metadata, "names")
+		delete(metadata, "qualified_names")
+		delete(metadata, "signatures")
+		delete(metadata, "const_values")
+
+		metadata["entity_name"] = name
+		metadata["qualified_name"] = qualifiedNames[i]
+		if signatures[i] != "" {
+			metadata["signature"] = signatures[i]
+		}
+		metadata["is_exported"] = valueSpec.Names[i].IsExported()
+		for _, value := range constValues {
+			if value["name"] == name {
+				metadata["const_type"] = value["type"]
+				metadata["const_value"] = value["value"]
+			}
+		}
+
+		chunks = append(chunks, types.ChromaDocument{
+			ID:       fmt.Sprintf("%s:%d-%d-%s", filePath, startLine, endLine, name),
+			Document: chunk.Document,
+			Metadata: metadata,
+		})
+	}
+	return chunks
+}
@@ -0,0 +1,92 @@
+package parser
+
+import (
+	"go/ast"
+	"go/types"
+	"log/slog"
+	"time"
+
+	"github.com/sunku5494/go-ast-parser/pkg/stats"
+	"github.com/sunku5494/go-ast-parser/pkg/transform"
+)
+
+// Options configures chunk extraction. The zero value extracts all
+// declarations with default settings.
+type Options struct {
+	// Logger receives diagnostics; defaults to slog.Default().
+	Logger *slog.Logger
+
+	// Timings, when set, accumulates the time spent in the parse, analyze and
+	// transform phases.
+	Timings *stats.Timings
+
+	// OnSkip, when set, is called for every package, file or declaration that
+	// could not be turned into chunks.
+	OnSkip func(stats.Skip)
+
+	// VendoredExportedOnly drops unexported declarations of vendored
+	// packages, keeping only their exported API.
+	VendoredExportedOnly bool
+
+	// SplitValueSpecs emits one chunk per name for const and var specs
+	// declaring several names, such as `var a, b, c int`.
+	SplitValueSpecs bool
+
+	// ClosureMinLines, when positive, extracts function literals spanning at
+	// least this many lines as separate "closure" chunks.
+	ClosureMinLines int
+
+	// NonGoFiles also extracts chunks for go.mod and go.work, embedded files,
+	// assembly and other non-Go sources, and .proto files.
+	NonGoFiles bool
+
+	// ExcludeGenerated skips files marked with a `// Code generated ... DO
+	// NOT EDIT.` header.
+	ExcludeGenerated bool
+
+	// Filter restricts the extracted files and chunks.
+	Filter Filter
+
+	// KeepPackageQualifiers leaves package qualifiers in chunk code as
+	// written instead of replacing them with full import paths.
+	KeepPackageQualifiers bool
+
+	// ExcludeMetadata lists metadata fields removed from every chunk.
+	ExcludeMetadata []string
+
+	// PackageTimeout, when positive, bounds the time spent on a single
+	// package. Packages exceeding it are skipped with a "package timeout"
+	// diagnostic.
+	PackageTimeout time.Duration
+
+	// sources caches file contents for the duration of a run
+	sources *sourceCache
+
+	// seenFiles records the non-Go files already emitted when parsing in
+	// batches
+	seenFiles map[string]bool
+}
+
+// logger returns the configured logger.
+func (o *Options) logger() *slog.Logger {
+	if o.Logger != nil {
+		return o.Logger
+	}
+	return slog.Default()
+}
+
+// skip reports a skipped item to the OnSkip callback, if any.
+func (o *Options) skip(s stats.Skip) {
+	if o.OnSkip != nil {
+		o.OnSkip(s)
+	}
+}
+
+// transform applies the configured code transformations to a chunk's code.
+func (o *Options) transform(code string, node ast.Node, info *types.Info) string {
+	if o.KeepPackageQualifiers {
+		return code
+	}
+	defer o.Timings.Track("transform")()
+	return transform.ApplyQualifierReplacements(code, node, info)
+}
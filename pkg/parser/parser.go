@@ -1,26 +1,57 @@
 package parser
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"go/ast"
 	"go/token"
-	"io/ioutil"
-	"log"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"golang.org/x/tools/go/packages"
 
 	"github.com/sunku5494/go-ast-parser/pkg/analyzer"
-	"github.com/sunku5494/go-ast-parser/pkg/transform"
+	"github.com/sunku5494/go-ast-parser/pkg/stats"
 	"github.com/sunku5494/go-ast-parser/pkg/types"
 )
 
 // ParsePackages extracts code chunks from loaded Go packages.
 // It processes each package's AST to create documented chunks with metadata.
 func ParsePackages(allPkgs []*packages.Package, projectPath string) ([]types.ChromaDocument, error) {
+	return ParsePackagesWithOptions(allPkgs, projectPath, Options{})
+}
+
+// ParsePackagesWithOptions extracts code chunks from loaded Go packages
+// like ParsePackages, configured by opts.
+func ParsePackagesWithOptions(allPkgs []*packages.Package, projectPath string, opts Options) ([]types.ChromaDocument, error) {
+	return ParsePackagesContext(context.Background(), allPkgs, projectPath, opts)
+}
+
+// ParsePackagesContext is like ParsePackagesWithOptions. When ctx is
+// cancelled, it returns the chunks of the packages completed so far together
+// with ctx's error.
+func ParsePackagesContext(ctx context.Context, allPkgs []*packages.Package, projectPath string, opts Options) ([]types.ChromaDocument, error) {
 	var allChunks []types.ChromaDocument
 
+	// Time spent reading, analyzing and transforming is tracked separately
+	// from parsing
+	start := time.Now()
+	nestedPhases := []string{"read", "analyze", "transform"}
+	before := make(map[string]time.Duration)
+	for _, phase := range nestedPhases {
+		before[phase] = opts.Timings.Get(phase)
+	}
+	defer func() {
+		elapsed := time.Since(start)
+		for _, phase := range nestedPhases {
+			elapsed -= opts.Timings.Get(phase) - before[phase]
+		}
+		opts.Timings.Add("parse", elapsed)
+	}()
+	opts.sources = newSourceCache(opts.Timings)
+
 	// Resolve the absolute path of the vendor directory once for `is_vendored` check
 	vendorDirPath := filepath.Join(projectPath, "vendor")
 	absVendorPath, err := filepath.Abs(vendorDirPath)
@@ -29,59 +60,126 @@ func ParsePackages(allPkgs []*packages.Package, projectPath string) ([]types.Chr
 	}
 
 	// Process all unique packages
+	var runErr error
 	for _, pkg := range allPkgs {
+		if runErr = ctx.Err(); runErr != nil {
+			break
+		}
 		if pkg.TypesInfo == nil || pkg.Syntax == nil || pkg.Fset == nil {
-			log.Printf("Skipping package %s due to missing type information, syntax trees, or fileset.", pkg.ID)
+			opts.logger().Warn("Skipping package due to missing type information, syntax trees, or fileset", "package", pkg.ID)
+			opts.skip(stats.Skip{Package: pkg.ID, Reason: "missing type information"})
 			continue
 		}
 
-		chunks, err := processPackage(pkg, absVendorPath)
+		pkgCtx, cancel := ctx, context.CancelFunc(func() {})
+		if opts.PackageTimeout > 0 {
+			pkgCtx, cancel = context.WithTimeout(ctx, opts.PackageTimeout)
+		}
+		chunks, err := processPackage(pkgCtx, pkg, projectPath, absVendorPath, &opts)
+		cancel()
+
+		if runErr = ctx.Err(); runErr != nil {
+			// The partially processed package is dropped
+			break
+		}
+		if errors.Is(err, context.DeadlineExceeded) {
+			opts.logger().Warn("Package processing timed out", "package", pkg.ID, "timeout", opts.PackageTimeout)
+			opts.skip(stats.Skip{Package: pkg.ID, Reason: "package timeout"})
+			continue
+		}
 		if err != nil {
-			log.Printf("Error processing package %s: %v", pkg.ID, err)
+			opts.logger().Error("Error processing package", "package", pkg.ID, "error", err)
+			opts.skip(stats.Skip{Package: pkg.ID, Reason: "package processing error"})
 			continue
 		}
 
 		allChunks = append(allChunks, chunks...)
 	}
 
-	return allChunks, nil
+	if opts.NonGoFiles && runErr == nil {
+		allChunks = append(allChunks, fileChunks(allPkgs, projectPath, absVendorPath, &opts)...)
+	}
+
+	for _, chunk := range allChunks {
+		for _, key := range opts.ExcludeMetadata {
+			delete(chunk.Metadata, key)
+		}
+	}
+
+	return allChunks, runErr
 }
 
-// processPackage processes a single package and extracts all code chunks from it.
-func processPackage(pkg *packages.Package, absVendorPath string) ([]types.ChromaDocument, error) {
+// processPackage processes a single package and extracts all code chunks from
+// it. Cancellation of ctx is checked between declarations.
+func processPackage(ctx context.Context, pkg *packages.Package, projectPath, absVendorPath string, opts *Options) ([]types.ChromaDocument, error) {
 	var chunks []types.ChromaDocument
 
 	for _, file := range pkg.Syntax {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
 		filePath := pkg.Fset.File(file.Pos()).Name()
-		originalFileBytes, err := ioutil.ReadFile(filePath)
+		if !opts.Filter.IncludesFile(projectPath, filePath) {
+			continue
+		}
+		if opts.ExcludeGenerated && ast.IsGenerated(file) {
+			opts.skip(stats.Skip{Package: pkg.ID, File: filePath, Reason: "generated file"})
+			continue
+		}
+		originalFileContentString, err := opts.sources.read(filePath)
 		if err != nil {
-			log.Printf("Error reading file %s: %v", filePath, err)
+			opts.logger().Error("Error reading file", "file", filePath, "error", err)
+			opts.skip(stats.Skip{Package: pkg.ID, File: filePath, Reason: "file read error"})
 			continue
 		}
 
 		packageName := pkg.Name
-		originalFileContentString := string(originalFileBytes)
 
 		// Determine if the file is from the vendor directory using a robust check
 		isVendored := strings.HasPrefix(filePath, absVendorPath+string(filepath.Separator))
 
-		fileChunks := processFileDeclarations(file, pkg, filePath, packageName, isVendored, originalFileContentString)
+		fileChunks := processFileDeclarations(ctx, file, pkg, filePath, packageName, isVendored, originalFileContentString, opts)
+		opts.sources.release(filePath)
 		chunks = append(chunks, fileChunks...)
 	}
 
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
 	return chunks, nil
 }
 
-// processFileDeclarations processes all declarations in a single file.
-func processFileDeclarations(file *ast.File, pkg *packages.Package, filePath, packageName string, isVendored bool, originalFileContentString string) []types.ChromaDocument {
+// processFileDeclarations processes all declarations in a single file,
+// stopping early when ctx is cancelled.
+func processFileDeclarations(ctx context.Context, file *ast.File, pkg *packages.Package, filePath, packageName string, isVendored bool, originalFileContentString string, opts *Options) []types.ChromaDocument {
 	var chunks []types.ChromaDocument
+	constraint := buildConstraint(file, pkg)
+	comments := lineComments(file, pkg)
+	generator, isGenerated := analyzer.GeneratedBy(file)
 
 	for _, decl := range file.Decls {
+		if ctx.Err() != nil {
+			break
+		}
 		metadata := map[string]interface{}{
 			"file_path":    filePath,
 			"package_name": packageName,
+			"package_path": pkg.PkgPath,
 			"is_vendored":  isVendored,
 		}
+		if constraint != "" {
+			metadata["build_constraint"] = constraint
+		}
+		metadata["is_generated"] = isGenerated
+		if generator != "" {
+			metadata["generator"] = generator
+		}
+		switch d := decl.(type) {
+		case *ast.FuncDecl:
+			setDirectives(metadata, declDirectives(comments, pkg, d, d.Doc))
+		case *ast.GenDecl:
+			setDirectives(metadata, analyzer.Directives(pkg.Fset, d.Doc))
+		}
 
 		startPos := pkg.Fset.Position(decl.Pos())
 		endPos := pkg.Fset.Position(decl.End())
@@ -90,54 +188,89 @@ func processFileDeclarations(file *ast.File, pkg *packages.Package, filePath, pa
 		endOffset := endPos.Offset
 
 		if startOffset < 0 || endOffset > len(originalFileContentString) || startOffset > endOffset {
-			log.Printf("Warning: Invalid offsets for declaration in %s (line %d): start=%d, end=%d, file_len=%d. Skipping declaration.",
-				filePath, startPos.Line, startOffset, endOffset, len(originalFileContentString))
+			opts.logger().Warn("Skipping declaration with invalid offsets", "file", filePath, "line", startPos.Line,
+				"start", startOffset, "end", endOffset, "file_len", len(originalFileContentString))
+			opts.skip(stats.Skip{Package: pkg.ID, File: filePath, Line: startPos.Line, Reason: "invalid declaration offsets"})
 			continue
 		}
 		declChunkCode := originalFileContentString[startOffset:endOffset]
 
 		// Extract all accessed symbols for this declaration
+		stopAnalyze := opts.Timings.Track("analyze")
 		accessedSymbols := analyzer.ExtractAccessedSymbols(decl, pkg.TypesInfo)
+		stopAnalyze()
 		metadata["accessed_symbols"] = accessedSymbols
 
-		declChunks := processDeclaration(decl, pkg, declChunkCode, metadata, filePath, startPos, endPos)
-		if declChunks != nil {
-			chunks = append(chunks, declChunks...)
+		declChunks := processDeclaration(decl, pkg, declChunkCode, metadata, filePath, startPos, endPos, opts)
+		declChunks = append(declChunks, closureChunks(decl, pkg, declChunks, originalFileContentString, opts)...)
+		for _, chunk := range declChunks {
+			if isVendored && opts.VendoredExportedOnly && chunk.Metadata["is_exported"] != true {
+				continue
+			}
+			if !opts.Filter.includeChunk(chunk) {
+				continue
+			}
+			chunks = append(chunks, chunk)
 		}
 	}
 
+	fileMetadata := map[string]interface{}{
+		"file_path":    filePath,
+		"package_name": packageName,
+		"package_path": pkg.PkgPath,
+		"is_vendored":  isVendored,
+		"is_generated": isGenerated,
+	}
+	if chunk := generateChunk(file, pkg, filePath, fileMetadata); chunk != nil && opts.Filter.includeChunk(*chunk) {
+		chunks = append(chunks, *chunk)
+	}
+
 	return chunks
 }
 
 // processDeclaration processes a single AST declaration and returns ChromaDocuments.
-func processDeclaration(decl ast.Decl, pkg *packages.Package, declChunkCode string, metadata map[string]interface{}, filePath string, startPos, endPos token.Position) []types.ChromaDocument {
+func processDeclaration(decl ast.Decl, pkg *packages.Package, declChunkCode string, metadata map[string]interface{}, filePath string, startPos, endPos token.Position, opts *Options) []types.ChromaDocument {
 	switch d := decl.(type) {
 	case *ast.FuncDecl:
-		chunk := processFunctionDeclaration(d, pkg, declChunkCode, metadata, filePath, startPos, endPos)
+		chunk := processFunctionDeclaration(d, pkg, declChunkCode, metadata, filePath, startPos, endPos, opts)
 		if chunk != nil {
 			return []types.ChromaDocument{*chunk}
 		}
 		return nil
 	case *ast.GenDecl:
-		return processGeneralDeclaration(d, pkg, declChunkCode, metadata, filePath, startPos, endPos)
+		return processGeneralDeclaration(d, pkg, declChunkCode, metadata, filePath, startPos, endPos, opts)
 	default:
+		opts.skip(stats.Skip{Package: pkg.ID, File: filePath, Line: startPos.Line, Reason: "unsupported declaration"})
 		return nil
 	}
 }
 
 // processFunctionDeclaration processes function and method declarations.
-func processFunctionDeclaration(funcDecl *ast.FuncDecl, pkg *packages.Package, declChunkCode string, metadata map[string]interface{}, filePath string, startPos, endPos token.Position) *types.ChromaDocument {
+func processFunctionDeclaration(funcDecl *ast.FuncDecl, pkg *packages.Package, declChunkCode string, metadata map[string]interface{}, filePath string, startPos, endPos token.Position, opts *Options) *types.ChromaDocument {
 	metadata["entity_type"] = "function"
 	metadata["entity_name"] = funcDecl.Name.Name
+	metadata["qualified_name"] = analyzer.QualifiedName(pkg.PkgPath, "", funcDecl.Name.Name)
+	metadata["start_line"] = startPos.Line
+	metadata["end_line"] = endPos.Line
+	stopAnalyze := opts.Timings.Track("analyze")
+	if obj := pkg.TypesInfo.Defs[funcDecl.Name]; obj != nil {
+		metadata["signature"] = analyzer.ObjectSignature(obj)
+	}
+	addFunctionMetrics(funcDecl, pkg, metadata)
+	stopAnalyze()
+	setVisibility(metadata, funcDecl.Name.IsExported(), funcDecl.Doc)
 
 	if funcDecl.Recv != nil && len(funcDecl.Recv.List) > 0 {
 		metadata["entity_type"] = "method"
+		// Methods of unexported types are not part of the exported API
+		metadata["is_exported"] = funcDecl.Name.IsExported() && token.IsExported(analyzer.ReceiverTypeName(funcDecl.Recv.List[0].Type))
 		receiverType := analyzer.GetTypeString(funcDecl.Recv.List[0].Type, pkg.TypesInfo)
 		metadata["receiver_type"] = receiverType
 		metadata["entity_name"] = receiverType + "." + funcDecl.Name.Name
+		metadata["qualified_name"] = analyzer.QualifiedName(pkg.PkgPath, analyzer.ReceiverTypeName(funcDecl.Recv.List[0].Type), funcDecl.Name.Name)
 	}
 
-	finalChunkCode := transform.ApplyQualifierReplacements(declChunkCode, funcDecl, pkg.TypesInfo)
+	finalChunkCode := opts.transform(declChunkCode, funcDecl, pkg.TypesInfo)
 
 	return &types.ChromaDocument{
 		ID:       fmt.Sprintf("%s:%d-%d-%s", filePath, startPos.Line, endPos.Line, funcDecl.Name.Name),
@@ -147,7 +280,7 @@ func processFunctionDeclaration(funcDecl *ast.FuncDecl, pkg *packages.Package, d
 }
 
 // processGeneralDeclaration processes type, const, and var declarations and returns all chunks.
-func processGeneralDeclaration(genDecl *ast.GenDecl, pkg *packages.Package, declChunkCode string, metadata map[string]interface{}, filePath string, startPos, endPos token.Position) []types.ChromaDocument {
+func processGeneralDeclaration(genDecl *ast.GenDecl, pkg *packages.Package, declChunkCode string, metadata map[string]interface{}, filePath string, startPos, endPos token.Position, opts *Options) []types.ChromaDocument {
 	if genDecl.Tok == token.IMPORT {
 		return nil // Skip import declarations
 	}
@@ -159,8 +292,19 @@ func processGeneralDeclaration(genDecl *ast.GenDecl, pkg *packages.Package, decl
 		specStartPos := pkg.Fset.Position(spec.Pos())
 		specEndPos := pkg.Fset.Position(spec.End())
 		
-		chunk := processSpecification(spec, genDecl, pkg, metadata, filePath, specStartPos, specEndPos)
-		if chunk != nil {
+		chunk := processSpecification(spec, genDecl, pkg, metadata, filePath, specStartPos, specEndPos, opts)
+		if chunk == nil {
+			continue
+		}
+		if valueSpec, ok := spec.(*ast.ValueSpec); ok && opts.SplitValueSpecs {
+			chunks = append(chunks, splitValueChunk(*chunk, valueSpec, pkg)...)
+		} else {
+			chunks = append(chunks, *chunk)
+		}
+	}
+
+	if genDecl.Tok == token.CONST {
+		if chunk := enumChunk(genDecl, pkg, metadata, filePath, startPos, endPos); chunk != nil {
 			chunks = append(chunks, *chunk)
 		}
 	}
@@ -169,55 +313,70 @@ func processGeneralDeclaration(genDecl *ast.GenDecl, pkg *packages.Package, decl
 }
 
 // processSpecification processes individual specifications (type, const, var).
-func processSpecification(spec ast.Spec, genDecl *ast.GenDecl, pkg *packages.Package, baseMetadata map[string]interface{}, filePath string, specStartPos, specEndPos token.Position) *types.ChromaDocument {
+func processSpecification(spec ast.Spec, genDecl *ast.GenDecl, pkg *packages.Package, baseMetadata map[string]interface{}, filePath string, specStartPos, specEndPos token.Position, opts *Options) *types.ChromaDocument {
 	// Create a copy of the base metadata for this specification
 	specMetadata := make(map[string]interface{})
 	for k, v := range baseMetadata {
 		specMetadata[k] = v
 	}
 
+	// A doc comment on the spec takes precedence over one on its group
 	switch s := spec.(type) {
 	case *ast.TypeSpec:
-		return processTypeSpecification(s, pkg, specMetadata, filePath, specStartPos, specEndPos)
+		setVisibility(specMetadata, s.Name.IsExported(), s.Doc, genDecl.Doc)
+		setDirectives(specMetadata, analyzer.Directives(pkg.Fset, s.Doc, s.Comment))
+		return processTypeSpecification(s, pkg, specMetadata, filePath, specStartPos, specEndPos, opts)
 	case *ast.ValueSpec:
-		return processValueSpecification(s, genDecl, pkg, specMetadata, filePath, specStartPos, specEndPos)
+		setVisibility(specMetadata, anyExported(s.Names), s.Doc, genDecl.Doc)
+		setDirectives(specMetadata, analyzer.Directives(pkg.Fset, s.Doc, s.Comment))
+		return processValueSpecification(s, genDecl, pkg, specMetadata, filePath, specStartPos, specEndPos, opts)
 	default:
 		return nil
 	}
 }
 
 // processTypeSpecification processes type declarations (struct, interface, etc.).
-func processTypeSpecification(typeSpec *ast.TypeSpec, pkg *packages.Package, specMetadata map[string]interface{}, filePath string, specStartPos, specEndPos token.Position) *types.ChromaDocument {
+func processTypeSpecification(typeSpec *ast.TypeSpec, pkg *packages.Package, specMetadata map[string]interface{}, filePath string, specStartPos, specEndPos token.Position, opts *Options) *types.ChromaDocument {
 	entityName := typeSpec.Name.Name
 	specMetadata["entity_name"] = entityName
-
-	if _, isStruct := typeSpec.Type.(*ast.StructType); isStruct {
-		specMetadata["entity_type"] = "struct"
-	} else if _, isInterface := typeSpec.Type.(*ast.InterfaceType); isInterface {
-		specMetadata["entity_type"] = "interface"
-	} else {
-		specMetadata["entity_type"] = "alias_or_basic"
+	specMetadata["qualified_name"] = analyzer.QualifiedName(pkg.PkgPath, "", entityName)
+	specMetadata["start_line"] = specStartPos.Line
+	specMetadata["end_line"] = specEndPos.Line
+
+	obj := pkg.TypesInfo.Defs[typeSpec.Name]
+	specMetadata["entity_type"] = typeEntityType(typeSpec, obj)
+	if obj != nil {
+		specMetadata["signature"] = analyzer.ObjectSignature(obj)
+		specMetadata["underlying_type"] = underlyingType(obj)
+	}
+	if structType, isStruct := typeSpec.Type.(*ast.StructType); isStruct {
+		specMetadata["fields"] = structFields(structType, pkg)
 	}
 
-	// Read the original code for this specification
-	originalFileBytes, err := ioutil.ReadFile(filePath)
+	stopAnalyze := opts.Timings.Track("analyze")
+	addEmbedding(typeSpec, pkg, specMetadata)
+	stopAnalyze()
+
+	// The file has already been read by processPackage
+	originalFileContentString, err := opts.sources.read(filePath)
 	if err != nil {
-		log.Printf("Error reading file %s: %v", filePath, err)
+		opts.logger().Error("Error reading file", "file", filePath, "error", err)
+		opts.skip(stats.Skip{Package: pkg.ID, File: filePath, Line: specStartPos.Line, Reason: "file read error"})
 		return nil
 	}
-	originalFileContentString := string(originalFileBytes)
 	
 	specStartOffset := specStartPos.Offset
 	specEndOffset := specEndPos.Offset
 	
 	if specStartOffset < 0 || specEndOffset > len(originalFileContentString) || specStartOffset > specEndOffset {
-		log.Printf("Warning: Invalid offsets for spec in %s (line %d): start=%d, end=%d, file_len=%d. Skipping spec.",
-			filePath, specStartPos.Line, specStartOffset, specEndOffset, len(originalFileContentString))
+		opts.logger().Warn("Skipping spec with invalid offsets", "file", filePath, "line", specStartPos.Line,
+			"start", specStartOffset, "end", specEndOffset, "file_len", len(originalFileContentString))
+		opts.skip(stats.Skip{Package: pkg.ID, File: filePath, Line: specStartPos.Line, Reason: "invalid declaration offsets"})
 		return nil
 	}
 	specChunkCode := originalFileContentString[specStartOffset:specEndOffset]
 
-	finalChunkCode := transform.ApplyQualifierReplacements(specChunkCode, typeSpec, pkg.TypesInfo)
+	finalChunkCode := opts.transform(specChunkCode, typeSpec, pkg.TypesInfo)
 
 	return &types.ChromaDocument{
 		ID:       fmt.Sprintf("%s:%d-%d-%s", filePath, specStartPos.Line, specEndPos.Line, entityName),
@@ -227,40 +386,51 @@ func processTypeSpecification(typeSpec *ast.TypeSpec, pkg *packages.Package, spe
 }
 
 // processValueSpecification processes const and var declarations.
-func processValueSpecification(valueSpec *ast.ValueSpec, genDecl *ast.GenDecl, pkg *packages.Package, specMetadata map[string]interface{}, filePath string, specStartPos, specEndPos token.Position) *types.ChromaDocument {
+func processValueSpecification(valueSpec *ast.ValueSpec, genDecl *ast.GenDecl, pkg *packages.Package, specMetadata map[string]interface{}, filePath string, specStartPos, specEndPos token.Position, opts *Options) *types.ChromaDocument {
 	var names []string
 	for _, name := range valueSpec.Names {
 		names = append(names, name.Name)
 	}
 	entityName := strings.Join(names, ", ")
 	specMetadata["entity_name"] = entityName
+	if len(names) == 1 {
+		specMetadata["qualified_name"] = analyzer.QualifiedName(pkg.PkgPath, "", entityName)
+		if obj := pkg.TypesInfo.Defs[valueSpec.Names[0]]; obj != nil {
+			specMetadata["signature"] = analyzer.ObjectSignature(obj)
+		}
+	}
+	addValueNames(valueSpec, pkg, specMetadata)
+	specMetadata["start_line"] = specStartPos.Line
+	specMetadata["end_line"] = specEndPos.Line
 	
 	// Set entity_type based on the declaration token (const or var)
 	if genDecl.Tok == token.CONST {
 		specMetadata["entity_type"] = "const"
+		addConstValues(valueSpec, pkg, specMetadata)
 	} else if genDecl.Tok == token.VAR {
 		specMetadata["entity_type"] = "var"
 	}
 
-	// Read the original code for this specification
-	originalFileBytes, err := ioutil.ReadFile(filePath)
+	// The file has already been read by processPackage
+	originalFileContentString, err := opts.sources.read(filePath)
 	if err != nil {
-		log.Printf("Error reading file %s: %v", filePath, err)
+		opts.logger().Error("Error reading file", "file", filePath, "error", err)
+		opts.skip(stats.Skip{Package: pkg.ID, File: filePath, Line: specStartPos.Line, Reason: "file read error"})
 		return nil
 	}
-	originalFileContentString := string(originalFileBytes)
 	
 	specStartOffset := specStartPos.Offset
 	specEndOffset := specEndPos.Offset
 	
 	if specStartOffset < 0 || specEndOffset > len(originalFileContentString) || specStartOffset > specEndOffset {
-		log.Printf("Warning: Invalid offsets for spec in %s (line %d): start=%d, end=%d, file_len=%d. Skipping spec.",
-			filePath, specStartPos.Line, specStartOffset, specEndOffset, len(originalFileContentString))
+		opts.logger().Warn("Skipping spec with invalid offsets", "file", filePath, "line", specStartPos.Line,
+			"start", specStartOffset, "end", specEndOffset, "file_len", len(originalFileContentString))
+		opts.skip(stats.Skip{Package: pkg.ID, File: filePath, Line: specStartPos.Line, Reason: "invalid declaration offsets"})
 		return nil
 	}
 	specChunkCode := originalFileContentString[specStartOffset:specEndOffset]
 
-	finalChunkCode := transform.ApplyQualifierReplacements(specChunkCode, valueSpec, pkg.TypesInfo)
+	finalChunkCode := opts.transform(specChunkCode, valueSpec, pkg.TypesInfo)
 
 	return &types.ChromaDocument{
 		ID:       fmt.Sprintf("%s:%d-%d-%s", filePath, specStartPos.Line, specEndPos.Line, entityName),
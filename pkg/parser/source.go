@@ -0,0 +1,41 @@
+package parser
+
+import (
+	"os"
+
+	"github.com/sunku5494/go-ast-parser/pkg/stats"
+)
+
+// sourceCache holds the content of the source files of a run, so that each
+// file is read from disk once however many declarations it has.
+type sourceCache struct {
+	timings *stats.Timings
+	files   map[string]string
+}
+
+// newSourceCache creates an empty cache recording read times in timings.
+func newSourceCache(timings *stats.Timings) *sourceCache {
+	return &sourceCache{timings: timings, files: make(map[string]string)}
+}
+
+// read returns the content of filePath, reading it on first use.
+func (c *sourceCache) read(filePath string) (string, error) {
+	if content, ok := c.files[filePath]; ok {
+		return content, nil
+	}
+
+	defer c.timings.Track("read")()
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return "", err
+	}
+	content := string(data)
+	c.files[filePath] = content
+	return content, nil
+}
+
+// release drops the content of filePath once all its declarations have been
+// processed, so that only the files in progress are kept in memory.
+func (c *sourceCache) release(filePath string) {
+	delete(c.files, filePath)
+}
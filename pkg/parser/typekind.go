@@ -0,0 +1,86 @@
+package parser
+
+import (
+	"go/ast"
+	"go/types"
+)
+
+// typeEntityType classifies a type declaration into its entity_type:
+//
+//	alias        type A = B
+//	constraint   interface with a type set, e.g. interface{ ~int | ~float64 }
+//	generic      type List[T any] ...
+//	struct       struct types
+//	interface    interfaces with only methods
+//	func_type    func types
+//	map_type, slice_type, array_type, chan_type, pointer_type
+//	basic_type   defined types over basic types, e.g. type Kind string
+//
+// A type is classified by the first matching rule: alias, constraint, then
+// generic, so a parameterized constraint such as
+// `type Number[T any] interface{ ~int | ~float64 }` is a constraint. Types
+// defined in terms of another named type are classified by their underlying
+// type. Without type information only the syntax is used.
+func typeEntityType(typeSpec *ast.TypeSpec, obj types.Object) string {
+	if typeSpec.Assign.IsValid() {
+		return "alias"
+	}
+
+	var underlying types.Type
+	if obj != nil {
+		underlying = obj.Type().Underlying()
+	}
+	if iface, ok := underlying.(*types.Interface); ok && !iface.IsMethodSet() {
+		return "constraint"
+	}
+	if typeSpec.TypeParams != nil && len(typeSpec.TypeParams.List) > 0 {
+		return "generic"
+	}
+
+	switch underlying.(type) {
+	case *types.Interface:
+		return "interface"
+	case *types.Struct:
+		return "struct"
+	case *types.Signature:
+		return "func_type"
+	case *types.Map:
+		return "map_type"
+	case *types.Slice:
+		return "slice_type"
+	case *types.Array:
+		return "array_type"
+	case *types.Chan:
+		return "chan_type"
+	case *types.Pointer:
+		return "pointer_type"
+	case *types.Basic:
+		return "basic_type"
+	}
+
+	switch t := typeSpec.Type.(type) {
+	case *ast.StructType:
+		return "struct"
+	case *ast.InterfaceType:
+		return "interface"
+	case *ast.FuncType:
+		return "func_type"
+	case *ast.MapType:
+		return "map_type"
+	case *ast.ChanType:
+		return "chan_type"
+	case *ast.ArrayType:
+		if t.Len != nil {
+			return "array_type"
+		}
+		return "slice_type"
+	default:
+		return "defined_type"
+	}
+}
+
+// underlyingType returns the underlying type of a declared type, or of the
+// aliased type for aliases.
+func underlyingType(obj types.Object) string {
+	return types.TypeString(types.Unalias(obj.Type()).Underlying(), nil)
+}
@@ -0,0 +1,63 @@
+package parser
+
+import (
+	"go/ast"
+	"go/importer"
+	goparser "go/parser"
+	"go/token"
+	"go/types"
+	"testing"
+)
+
+func TestTypeEntityType(t *testing.T) {
+	const src = `package p
+
+type Alias = int
+type Number interface{ ~int | ~float64 }
+type GenericNumber[T any] interface{ ~int | ~float64 }
+type List[T any] []T
+type Stringer interface{ String() string }
+type GenericStringer[T any] interface{ String() T }
+type Point struct{ X, Y int }
+type Grid [4][4]int
+type Row []int
+type Kind string
+`
+	want := map[string]string{
+		"Alias":           "alias",
+		"Number":          "constraint",
+		"GenericNumber":   "constraint",
+		"List":            "generic",
+		"Stringer":        "interface",
+		"GenericStringer": "generic",
+		"Point":           "struct",
+		"Grid":            "array_type",
+		"Row":             "slice_type",
+		"Kind":            "basic_type",
+	}
+
+	fset := token.NewFileSet()
+	file, err := goparser.ParseFile(fset, "p.go", src, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	info := &types.Info{Defs: make(map[*ast.Ident]types.Object)}
+	conf := types.Config{Importer: importer.Default()}
+	if _, err := conf.Check("p", fset, []*ast.File{file}, info); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, decl := range file.Decls {
+		typeSpec := decl.(*ast.GenDecl).Specs[0].(*ast.TypeSpec)
+		name := typeSpec.Name.Name
+		if got := typeEntityType(typeSpec, info.Defs[typeSpec.Name]); got != want[name] {
+			t.Errorf("%s: got %q, want %q", name, got, want[name])
+		}
+		// The syntax alone tells arrays from slices
+		if name == "Grid" {
+			if got := typeEntityType(typeSpec, nil); got != "array_type" {
+				t.Errorf("%s without types: got %q, want %q", name, got, "array_type")
+			}
+		}
+	}
+}
@@ -0,0 +1,73 @@
+package parser
+
+import (
+	"go/ast"
+	"go/token"
+
+	"golang.org/x/tools/go/packages"
+
+	"github.com/sunku5494/go-ast-parser/pkg/analyzer"
+)
+
+// setVisibility stores whether an entity is exported and whether any of its
+// doc comments, innermost first, marks it as deprecated.
+func setVisibility(metadata map[string]interface{}, exported bool, docs ...*ast.CommentGroup) {
+	metadata["is_exported"] = exported
+	metadata["is_deprecated"] = false
+
+	for _, doc := range docs {
+		if message, ok := analyzer.DeprecationMessage(doc); ok {
+			metadata["is_deprecated"] = true
+			metadata["deprecation_message"] = message
+			return
+		}
+	}
+}
+
+// anyExported reports whether at least one of the names is exported.
+func anyExported(names []*ast.Ident) bool {
+	for _, name := range names {
+		if name.IsExported() {
+			return true
+		}
+	}
+	return false
+}
+
+// structFields describes the fields of a struct type, including their
+// visibility and deprecation.
+func structFields(structType *ast.StructType, pkg *packages.Package) []map[string]interface{} {
+	var fields []map[string]interface{}
+	for _, field := range structType.Fields.List {
+		fieldType := analyzer.GetTypeString(field.Type, pkg.TypesInfo)
+
+		names := field.Names
+		if len(names) == 0 {
+			// Embedded fields are named after their type
+			names = []*ast.Ident{ast.NewIdent(embeddedFieldName(field.Type))}
+		}
+
+		for _, name := range names {
+			metadata := map[string]interface{}{
+				"name": name.Name,
+				"type": fieldType,
+			}
+			setVisibility(metadata, token.IsExported(name.Name), field.Doc, field.Comment)
+			fields = append(fields, metadata)
+		}
+	}
+	return fields
+}
+
+// embeddedFieldName returns the implicit field name of an embedded type,
+// which is its unqualified type name.
+func embeddedFieldName(expr ast.Expr) string {
+	switch t := expr.(type) {
+	case *ast.StarExpr:
+		return embeddedFieldName(t.X)
+	case *ast.SelectorExpr:
+		return t.Sel.Name
+	default:
+		return analyzer.ReceiverTypeName(expr)
+	}
+}
@@ -0,0 +1,192 @@
+package server
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/sunku5494/go-ast-parser/pkg/index"
+	"github.com/sunku5494/go-ast-parser/pkg/types"
+)
+
+// Server exposes an Index over a JSON HTTP API.
+//
+// Endpoints:
+//
+//	GET /api/chunk?id=ID                        chunk by ID
+//	GET /api/search?q=TEXT&entity_type=&package=&limit=&min_complexity=&sort=complexity&exclude_generated=true
+//	GET /api/symbol?name=QUALIFIED_NAME         definitions of a symbol
+//	GET /api/references?symbol=QUALIFIED_NAME   chunks accessing a symbol
+//	GET /api/packages                           package overview
+//	GET /api/packages/symbols?package=PATH      symbols defined in a package
+//
+// References cover every chunk whose accessed_symbols include the symbol:
+// callers in the same package as well as in importing packages, and uses of
+// methods and fields given as `pkg.Type.Member`.
+type Server struct {
+	ix  *index.Index
+	mux *http.ServeMux
+}
+
+// SymbolSummary is a compact description of a chunk used in symbol listings.
+type SymbolSummary struct {
+	ID            string `json:"id"`
+	QualifiedName string `json:"qualified_name"`
+	EntityName    string `json:"entity_name"`
+	EntityType    string `json:"entity_type"`
+	FilePath      string `json:"file_path"`
+	StartLine     int    `json:"start_line"`
+	EndLine       int    `json:"end_line"`
+}
+
+// New creates a Server backed by the given index.
+func New(ix *index.Index) *Server {
+	s := &Server{ix: ix, mux: http.NewServeMux()}
+
+	s.mux.HandleFunc("GET /api/chunk", s.handleChunk)
+	s.mux.HandleFunc("GET /api/search", s.handleSearch)
+	s.mux.HandleFunc("GET /api/symbol", s.handleSymbol)
+	s.mux.HandleFunc("GET /api/references", s.handleReferences)
+	s.mux.HandleFunc("GET /api/packages", s.handlePackages)
+	s.mux.HandleFunc("GET /api/packages/symbols", s.handlePackageSymbols)
+
+	return s
+}
+
+// ServeHTTP implements http.Handler.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+// ListenAndServe serves the API on the given address until it fails.
+func (s *Server) ListenAndServe(addr string) error {
+	slog.Info("Serving code chunks", "chunks", len(s.ix.Chunks()), "addr", addr)
+	return http.ListenAndServe(addr, s)
+}
+
+func (s *Server) handleChunk(w http.ResponseWriter, r *http.Request) {
+	id := r.URL.Query().Get("id")
+	if id == "" {
+		writeError(w, http.StatusBadRequest, "missing id parameter")
+		return
+	}
+
+	chunk, ok := s.ix.Get(id)
+	if !ok {
+		writeError(w, http.StatusNotFound, "chunk not found: "+id)
+		return
+	}
+	writeJSON(w, http.StatusOK, chunk)
+}
+
+func (s *Server) handleSearch(w http.ResponseWriter, r *http.Request) {
+	params := r.URL.Query()
+
+	limit := 50
+	if v := params.Get("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			writeError(w, http.StatusBadRequest, "invalid limit: "+v)
+			return
+		}
+		limit = n
+	}
+
+	minComplexity := 0
+	if v := params.Get("min_complexity"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			writeError(w, http.StatusBadRequest, "invalid min_complexity: "+v)
+			return
+		}
+		minComplexity = n
+	}
+
+	results := s.ix.Search(index.Query{
+		Text:        params.Get("q"),
+		EntityType:  params.Get("entity_type"),
+		PackagePath: params.Get("package"),
+		Limit:       limit,
+
+		MinComplexity:    minComplexity,
+		SortByComplexity: params.Get("sort") == "complexity",
+		ExcludeGenerated: params.Get("exclude_generated") == "true",
+	})
+	if results == nil {
+		results = []index.SearchResult{}
+	}
+	writeJSON(w, http.StatusOK, results)
+}
+
+func (s *Server) handleSymbol(w http.ResponseWriter, r *http.Request) {
+	name := r.URL.Query().Get("name")
+	if name == "" {
+		writeError(w, http.StatusBadRequest, "missing name parameter")
+		return
+	}
+
+	definitions := s.ix.Lookup(name)
+	if len(definitions) == 0 {
+		writeError(w, http.StatusNotFound, "symbol not found: "+name)
+		return
+	}
+	writeJSON(w, http.StatusOK, definitions)
+}
+
+func (s *Server) handleReferences(w http.ResponseWriter, r *http.Request) {
+	symbol := r.URL.Query().Get("symbol")
+	if symbol == "" {
+		writeError(w, http.StatusBadRequest, "missing symbol parameter")
+		return
+	}
+	writeJSON(w, http.StatusOK, summarize(s.ix.References(symbol)))
+}
+
+func (s *Server) handlePackages(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, s.ix.Packages())
+}
+
+func (s *Server) handlePackageSymbols(w http.ResponseWriter, r *http.Request) {
+	pkgPath := r.URL.Query().Get("package")
+	if pkgPath == "" {
+		writeError(w, http.StatusBadRequest, "missing package parameter")
+		return
+	}
+
+	chunks := s.ix.PackageChunks(pkgPath)
+	if len(chunks) == 0 {
+		writeError(w, http.StatusNotFound, "package not found: "+pkgPath)
+		return
+	}
+	writeJSON(w, http.StatusOK, summarize(chunks))
+}
+
+// summarize converts chunks into their compact symbol descriptions.
+func summarize(chunks []types.ChromaDocument) []SymbolSummary {
+	summaries := make([]SymbolSummary, 0, len(chunks))
+	for _, chunk := range chunks {
+		summaries = append(summaries, SymbolSummary{
+			ID:            chunk.ID,
+			QualifiedName: index.MetadataString(chunk.Metadata, "qualified_name"),
+			EntityName:    index.MetadataString(chunk.Metadata, "entity_name"),
+			EntityType:    index.MetadataString(chunk.Metadata, "entity_type"),
+			FilePath:      index.MetadataString(chunk.Metadata, "file_path"),
+			StartLine:     index.MetadataInt(chunk.Metadata, "start_line"),
+			EndLine:       index.MetadataInt(chunk.Metadata, "end_line"),
+		})
+	}
+	return summaries
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		slog.Error("Error encoding JSON response", "error", err)
+	}
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, map[string]string{"error": message})
+}
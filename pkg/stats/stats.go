@@ -0,0 +1,208 @@
+package stats
+
+import (
+	"fmt"
+	"go/token"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	"github.com/sunku5494/go-ast-parser/pkg/types"
+)
+
+// Phases of a run, in pipeline order.
+var Phases = []string{"load", "read", "parse", "analyze", "transform", "write"}
+
+// Timings accumulates the time spent in each phase of a run.
+type Timings struct {
+	mu        sync.Mutex
+	durations map[string]time.Duration
+}
+
+// NewTimings creates an empty Timings.
+func NewTimings() *Timings {
+	return &Timings{durations: make(map[string]time.Duration)}
+}
+
+// Add adds d to the time spent in phase. It is safe to call on a nil Timings.
+func (t *Timings) Add(phase string, d time.Duration) {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.durations[phase] += d
+}
+
+// Get returns the time spent in phase.
+func (t *Timings) Get(phase string) time.Duration {
+	if t == nil {
+		return 0
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.durations[phase]
+}
+
+// Track starts timing phase; call the returned function when it ends.
+func (t *Timings) Track(phase string) func() {
+	start := time.Now()
+	return func() { t.Add(phase, time.Since(start)) }
+}
+
+// Skip records a package, file or declaration that produced no chunks.
+type Skip struct {
+	Package string `json:"package"`
+	File    string `json:"file,omitempty"`
+	Line    int    `json:"line,omitempty"`
+	Reason  string `json:"reason"`
+}
+
+// Report summarizes the chunks produced by a run.
+type Report struct {
+	TotalChunks      int                `json:"total_chunks"`
+	ByEntityType     map[string]int     `json:"by_entity_type"`
+	ByPackage        map[string]int     `json:"by_package"`
+	MainModuleChunks int                `json:"main_module_chunks"`
+	VendoredChunks   int                `json:"vendored_chunks"`
+	ExportedChunks   int                `json:"exported_chunks"`
+	UnexportedChunks int                `json:"unexported_chunks"`
+	AvgChunkSize     float64            `json:"avg_chunk_size"`
+	MaxChunkSize     int                `json:"max_chunk_size"`
+	MaxChunkID       string             `json:"max_chunk_id,omitempty"`
+	SkippedByReason  map[string]int     `json:"skipped_by_reason"`
+	Skipped          []Skip             `json:"skipped"`
+	PhaseMillis      map[string]float64 `json:"phase_millis"`
+}
+
+// Build computes a report from the produced chunks, the recorded skips and
+// the phase timings. Chunk sizes are measured in bytes of code.
+func Build(chunks []types.ChromaDocument, skipped []Skip, timings *Timings) Report {
+	if skipped == nil {
+		skipped = []Skip{}
+	}
+	report := Report{
+		TotalChunks:     len(chunks),
+		ByEntityType:    make(map[string]int),
+		ByPackage:       make(map[string]int),
+		SkippedByReason: make(map[string]int),
+		Skipped:         skipped,
+		PhaseMillis:     make(map[string]float64),
+	}
+
+	totalSize := 0
+	for _, chunk := range chunks {
+		entityType, _ := chunk.Metadata["entity_type"].(string)
+		pkgPath, _ := chunk.Metadata["package_path"].(string)
+		report.ByEntityType[entityType]++
+		report.ByPackage[pkgPath]++
+
+		if vendored, _ := chunk.Metadata["is_vendored"].(bool); vendored {
+			report.VendoredChunks++
+		} else {
+			report.MainModuleChunks++
+		}
+
+		if isExported(chunk) {
+			report.ExportedChunks++
+		} else {
+			report.UnexportedChunks++
+		}
+
+		size := len(chunk.Document)
+		totalSize += size
+		if size > report.MaxChunkSize {
+			report.MaxChunkSize = size
+			report.MaxChunkID = chunk.ID
+		}
+	}
+	if len(chunks) > 0 {
+		report.AvgChunkSize = float64(totalSize) / float64(len(chunks))
+	}
+
+	for _, skip := range skipped {
+		report.SkippedByReason[skip.Reason]++
+	}
+
+	for _, phase := range Phases {
+		report.PhaseMillis[phase] = float64(timings.Get(phase).Microseconds()) / 1000
+	}
+
+	return report
+}
+
+// isExported reports whether the chunk's entity is exported. Without
+// `is_exported` metadata every name component of the entity must be
+// exported, so that methods on unexported types count as unexported.
+func isExported(chunk types.ChromaDocument) bool {
+	if exported, ok := chunk.Metadata["is_exported"].(bool); ok {
+		return exported
+	}
+	name, _ := chunk.Metadata["entity_name"].(string)
+	if qualified, ok := chunk.Metadata["qualified_name"].(string); ok {
+		pkgPath, _ := chunk.Metadata["package_path"].(string)
+		name = strings.TrimPrefix(qualified, pkgPath+".")
+	}
+	if name == "" {
+		return false
+	}
+	for _, part := range strings.Split(name, ".") {
+		if !token.IsExported(strings.TrimSpace(strings.Split(part, ",")[0])) {
+			return false
+		}
+	}
+	return true
+}
+
+// WriteTable writes the report as human-readable tables.
+func (r Report) WriteTable(w io.Writer) {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+
+	fmt.Fprintf(tw, "Total chunks\t%d\n", r.TotalChunks)
+	fmt.Fprintf(tw, "Main module / vendored\t%d / %d\n", r.MainModuleChunks, r.VendoredChunks)
+	fmt.Fprintf(tw, "Exported / unexported\t%d / %d\n", r.ExportedChunks, r.UnexportedChunks)
+	fmt.Fprintf(tw, "Average chunk size\t%.1f bytes\n", r.AvgChunkSize)
+	fmt.Fprintf(tw, "Largest chunk\t%d bytes (%s)\n", r.MaxChunkSize, r.MaxChunkID)
+
+	fmt.Fprintf(tw, "\nENTITY TYPE\tCHUNKS\n")
+	for _, key := range sortedByCount(r.ByEntityType) {
+		fmt.Fprintf(tw, "%s\t%d\n", key, r.ByEntityType[key])
+	}
+
+	fmt.Fprintf(tw, "\nPACKAGE\tCHUNKS\n")
+	for _, key := range sortedByCount(r.ByPackage) {
+		fmt.Fprintf(tw, "%s\t%d\n", key, r.ByPackage[key])
+	}
+
+	if len(r.SkippedByReason) > 0 {
+		fmt.Fprintf(tw, "\nSKIP REASON\tCOUNT\n")
+		for _, key := range sortedByCount(r.SkippedByReason) {
+			fmt.Fprintf(tw, "%s\t%d\n", key, r.SkippedByReason[key])
+		}
+	}
+
+	fmt.Fprintf(tw, "\nPHASE\tMILLISECONDS\n")
+	for _, phase := range Phases {
+		fmt.Fprintf(tw, "%s\t%.1f\n", phase, r.PhaseMillis[phase])
+	}
+
+	tw.Flush()
+}
+
+// sortedByCount returns the keys of counts ordered by descending count, then by key.
+func sortedByCount(counts map[string]int) []string {
+	keys := make([]string, 0, len(counts))
+	for key := range counts {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if counts[keys[i]] != counts[keys[j]] {
+			return counts[keys[i]] > counts[keys[j]]
+		}
+		return keys[i] < keys[j]
+	})
+	return keys
+}
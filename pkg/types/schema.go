@@ -0,0 +1,102 @@
+package types
+
+// MetadataField describes a metadata key of ChromaDocument.
+type MetadataField struct {
+	Name        string
+	Type        string // JSON type: string, integer, boolean, array or object
+	Items       string // Element JSON type of arrays
+	Description string
+}
+
+// MetadataFields lists the metadata keys set by the parser. Most keys only
+// apply to some entity types and are omitted from other chunks.
+var MetadataFields = []MetadataField{
+	{"file_path", "string", "", "Absolute path of the source file"},
+	{"package_name", "string", "", "Name of the package; the file name for go_mod and go_work chunks"},
+	{"package_path", "string", "", "Import path of the package; the module path for go_mod chunks and `go.work` for go_work chunks"},
+	{"is_vendored", "boolean", "", "The file belongs to the vendor directory"},
+	{"entity_type", "string", "", "Kind of the chunk, e.g. function, method, struct, const, closure, go_mod"},
+	{"entity_name", "string", "", "Name of the entity; `Type.Method` for methods"},
+	{"qualified_name", "string", "", "Fully qualified name, `pkg.Type.Method` for methods and `pkg.Type.enum` for enums"},
+	{"signature", "string", "", "Fully qualified type of the entity, without parameter names"},
+	{"underlying_type", "string", "", "Underlying type of type declarations"},
+	{"receiver_type", "string", "", "Receiver type of methods"},
+	{"start_line", "integer", "", "First line of the chunk"},
+	{"end_line", "integer", "", "Last line of the chunk"},
+	{"is_exported", "boolean", "", "The entity is exported; methods also require an exported receiver type"},
+	{"is_deprecated", "boolean", "", "The doc comment has a `Deprecated:` paragraph"},
+	{"deprecation_message", "string", "", "Text of the `Deprecated:` paragraph"},
+	{"accessed_symbols", "array", "string", "Qualified package-level symbols, methods and fields used by the chunk, from any package"},
+	{"names", "array", "string", "Names declared by multi-name const and var specs"},
+	{"qualified_names", "array", "string", "Qualified names declared by multi-name const and var specs"},
+	{"signatures", "array", "string", "Types of the names of multi-name const and var specs"},
+	{"const_type", "string", "", "Type of constants"},
+	{"const_value", "string", "", "Computed value of constants"},
+	{"const_values", "array", "object", "Name and value of each constant of a multi-name spec"},
+	{"enum_type", "string", "", "Type of enum chunks"},
+	{"enum_values", "array", "object", "Name and value of each constant of enum chunks"},
+	{"has_string_method", "boolean", "", "The enum type has a String method"},
+	{"fields", "array", "object", "Name, type, visibility and deprecation of struct fields"},
+	{"embedded_types", "array", "object", "Types embedded in structs and interfaces"},
+	{"promoted_fields", "array", "object", "Fields promoted from embedded types"},
+	{"promoted_methods", "array", "object", "Methods promoted from embedded types"},
+	{"parent_id", "string", "", "ID of the chunk enclosing a closure"},
+	{"enclosing_function", "string", "", "Name of the function enclosing a closure"},
+	{"captured_variables", "array", "string", "Variables a closure captures from its enclosing function"},
+	{"closures", "array", "object", "Complexity metrics of the function literals of a function"},
+	{"cyclomatic_complexity", "integer", "", "Cyclomatic complexity of functions"},
+	{"cognitive_complexity", "integer", "", "Cognitive complexity of functions"},
+	{"max_nesting_depth", "integer", "", "Deepest nesting of control structures"},
+	{"statement_count", "integer", "", "Number of statements"},
+	{"parameter_count", "integer", "", "Number of parameters"},
+	{"lines_of_code", "integer", "", "Number of lines, including comments and blank lines"},
+	{"directives", "array", "object", "Directive comments such as //go:noinline and //nolint"},
+	{"build_constraint", "string", "", "The file's //go:build expression"},
+	{"is_generated", "boolean", "", "The file has a `// Code generated ... DO NOT EDIT.` header"},
+	{"generator", "string", "", "Tool named by the generated code header"},
+	{"generators", "array", "string", "Commands of the //go:generate directives of a file"},
+	{"embedded_by", "array", "string", "Variables whose //go:embed selects an embedded file"},
+	{"module_path", "string", "", "Module path of go.mod chunks"},
+	{"go_version", "string", "", "Go version of go.mod and go.work chunks"},
+	{"toolchain", "string", "", "Toolchain of go.mod and go.work chunks"},
+	{"requires", "array", "object", "Required modules of go.mod and go.work chunks"},
+	{"replaces", "array", "object", "Replacements of go.mod and go.work chunks"},
+	{"excludes", "array", "string", "Excluded `path@version`s of go.mod chunks"},
+	{"uses", "array", "string", "Module directories of go.work chunks"},
+	{"revision", "string", "", "Git revision the chunk was extracted from"},
+}
+
+// Schema returns a JSON Schema describing the chunk output, an array of
+// ChromaDocument.
+func Schema() map[string]interface{} {
+	properties := make(map[string]interface{}, len(MetadataFields))
+	for _, field := range MetadataFields {
+		property := map[string]interface{}{
+			"type":        field.Type,
+			"description": field.Description,
+		}
+		if field.Items != "" {
+			property["items"] = map[string]interface{}{"type": field.Items}
+		}
+		properties[field.Name] = property
+	}
+
+	return map[string]interface{}{
+		"$schema": "https://json-schema.org/draft/2020-12/schema",
+		"title":   "go-ast-parser code chunks",
+		"type":    "array",
+		"items": map[string]interface{}{
+			"type":     "object",
+			"required": []string{"id", "document", "metadata"},
+			"properties": map[string]interface{}{
+				"id":       map[string]interface{}{"type": "string", "description": "file_path:start_line-end_line-entity_name"},
+				"document": map[string]interface{}{"type": "string", "description": "Code of the chunk"},
+				"metadata": map[string]interface{}{
+					"type":       "object",
+					"required":   []string{"file_path", "entity_type", "entity_name", "start_line", "end_line"},
+					"properties": properties,
+				},
+			},
+		},
+	}
+}
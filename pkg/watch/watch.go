@@ -0,0 +1,357 @@
+package watch
+
+import (
+	"context"
+	"encoding/json"
+	"io/fs"
+	"log/slog"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"golang.org/x/tools/go/packages"
+
+	"github.com/sunku5494/go-ast-parser/pkg/loader"
+	"github.com/sunku5494/go-ast-parser/pkg/output"
+	"github.com/sunku5494/go-ast-parser/pkg/parser"
+	"github.com/sunku5494/go-ast-parser/pkg/types"
+)
+
+// Watcher polls a Go project for changes to `.go`, go.mod and go.work files,
+// and to all other files when ParseOptions.NonGoFiles is set, and pushes the
+// resulting chunk updates to a sink. Only the packages containing changed
+// files and their reverse dependencies are reloaded; a change to go.mod or
+// go.work reloads the whole project.
+type Watcher struct {
+	ProjectPath string
+	Interval    time.Duration // How often the tree is scanned
+	Debounce    time.Duration // Quiet period required before changes are processed
+	Sink        output.Sink
+
+	LoadOptions  loader.Options // Package selection, build tags and tests
+	ParseOptions parser.Options // Chunk extraction settings
+
+	files   map[string]fileState                       // Watched file -> last seen state
+	chunks  map[string]map[string]types.ChromaDocument // Chunk group (see chunkGroup) -> chunk ID -> chunk
+	dirs    map[string]string                          // Package directory -> package path
+	imports map[string][]string                        // Package path -> imported package paths
+}
+
+// fileState identifies a version of a file for change detection.
+type fileState struct {
+	modTime time.Time
+	size    int64
+}
+
+// New creates a Watcher with default polling and debounce intervals.
+func New(projectPath string, sink output.Sink) *Watcher {
+	return &Watcher{
+		ProjectPath: projectPath,
+		Interval:    500 * time.Millisecond,
+		Debounce:    1 * time.Second,
+		Sink:        sink,
+	}
+}
+
+// Run performs an initial full extraction and then watches for changes until
+// the context is cancelled.
+func (w *Watcher) Run(ctx context.Context) error {
+	w.files = w.scan()
+	if err := w.reloadAll(ctx); err != nil {
+		if ctx.Err() != nil {
+			return nil
+		}
+		return err
+	}
+	slog.Info("Watching files", "files", len(w.files), "dir", w.ProjectPath)
+
+	ticker := time.NewTicker(w.Interval)
+	defer ticker.Stop()
+
+	pending := make(map[string]bool)
+	var lastChange time.Time
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case now := <-ticker.C:
+			current := w.scan()
+			for _, path := range changedFiles(w.files, current) {
+				pending[path] = true
+				lastChange = now
+			}
+			w.files = current
+
+			// Wait until the tree has been quiet for the debounce period, so that
+			// bulk operations such as `git checkout` result in a single update
+			if len(pending) == 0 || now.Sub(lastChange) < w.Debounce {
+				continue
+			}
+
+			changed := make([]string, 0, len(pending))
+			for path := range pending {
+				changed = append(changed, path)
+			}
+			pending = make(map[string]bool)
+
+			if err := w.process(ctx, changed); err != nil && ctx.Err() == nil {
+				slog.Error("Error processing changes", "error", err)
+			}
+		}
+	}
+}
+
+// process reloads the packages affected by the changed files.
+func (w *Watcher) process(ctx context.Context, changed []string) error {
+	slog.Info("Detected changed files", "files", len(changed))
+
+	affected := make(map[string]bool)
+	var patterns []string
+	for _, path := range changed {
+		if isModuleFile(filepath.Base(path)) {
+			return w.reloadAll(ctx)
+		}
+
+		dir := filepath.Dir(path)
+		if pkgPath, ok := w.dirs[dir]; ok {
+			affected[pkgPath] = true
+			continue
+		}
+
+		// Other files belong to the package of the nearest enclosing
+		// directory, such as the files a package embeds from subdirectories
+		if !strings.HasSuffix(path, ".go") {
+			if pkgPath, ok := w.enclosingPackage(dir); ok {
+				affected[pkgPath] = true
+			}
+			continue
+		}
+
+		// A file in a directory without a known package: load it by directory
+		if rel, err := filepath.Rel(w.ProjectPath, dir); err == nil {
+			patterns = append(patterns, "./"+filepath.ToSlash(rel))
+		}
+	}
+
+	for pkgPath := range w.reverseDependencies(affected) {
+		affected[pkgPath] = true
+	}
+	for pkgPath := range affected {
+		patterns = append(patterns, pkgPath)
+	}
+	if len(patterns) == 0 {
+		return nil
+	}
+
+	pkgs, err := loader.LoadPackagesContext(ctx, w.ProjectPath, w.LoadOptions, patterns...)
+	if err != nil {
+		return err
+	}
+	return w.update(ctx, pkgs, affected)
+}
+
+// reloadAll reloads every package in the project.
+func (w *Watcher) reloadAll(ctx context.Context) error {
+	pkgs, err := loader.LoadGoProjectContext(ctx, w.ProjectPath, w.LoadOptions)
+	if err != nil {
+		return err
+	}
+
+	affected := make(map[string]bool)
+	for pkgPath := range w.chunks {
+		affected[pkgPath] = true
+	}
+	return w.update(ctx, pkgs, affected)
+}
+
+// update parses the loaded packages and sends changed and deleted chunks of
+// all affected packages to the sink. Nothing is pushed when ctx is cancelled,
+// as missing packages would otherwise be reported as deleted.
+func (w *Watcher) update(ctx context.Context, pkgs []*packages.Package, affected map[string]bool) error {
+	if w.chunks == nil {
+		w.chunks = make(map[string]map[string]types.ChromaDocument)
+		w.dirs = make(map[string]string)
+		w.imports = make(map[string][]string)
+	}
+
+	chunks, err := parser.ParsePackagesContext(ctx, pkgs, w.ProjectPath, w.ParseOptions)
+	if err != nil {
+		return err
+	}
+
+	fresh := make(map[string]map[string]types.ChromaDocument)
+	for _, pkg := range pkgs {
+		affected[pkg.PkgPath] = true
+		fresh[pkg.PkgPath] = make(map[string]types.ChromaDocument)
+
+		var imports []string
+		for _, imp := range pkg.Imports {
+			imports = append(imports, imp.PkgPath)
+		}
+		w.imports[pkg.PkgPath] = imports
+		if len(pkg.GoFiles) > 0 {
+			w.dirs[filepath.Dir(pkg.GoFiles[0])] = pkg.PkgPath
+		}
+	}
+	for _, chunk := range chunks {
+		group := chunkGroup(chunk)
+		affected[group] = true
+		if fresh[group] == nil {
+			fresh[group] = make(map[string]types.ChromaDocument)
+		}
+		fresh[group][chunk.ID] = chunk
+	}
+
+	var upserts []types.ChromaDocument
+	var deletes []string
+	for group := range affected {
+		previous := w.chunks[group]
+		current := fresh[group]
+
+		for id, chunk := range current {
+			if old, ok := previous[id]; !ok || !sameChunk(old, chunk) {
+				upserts = append(upserts, chunk)
+			}
+		}
+		for id := range previous {
+			if _, ok := current[id]; !ok {
+				deletes = append(deletes, id)
+			}
+		}
+
+		if len(current) == 0 {
+			delete(w.chunks, group)
+		} else {
+			w.chunks[group] = current
+		}
+	}
+
+	slog.Info("Pushing chunk updates", "changed", len(upserts), "deleted", len(deletes))
+	if len(upserts) > 0 {
+		if err := w.Sink.Upsert(upserts); err != nil {
+			return err
+		}
+	}
+	if len(deletes) > 0 {
+		if err := w.Sink.Delete(deletes); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// chunkGroup returns the key under which a chunk is tracked: its package
+// path, or for module-level files such as go.mod, which are extracted again
+// with every update, the file itself.
+func chunkGroup(chunk types.ChromaDocument) string {
+	filePath, _ := chunk.Metadata["file_path"].(string)
+	switch chunk.Metadata["entity_type"] {
+	case "go_mod", "go_work":
+		return "file:" + filePath
+	}
+	if pkgPath, _ := chunk.Metadata["package_path"].(string); pkgPath != "" {
+		return pkgPath
+	}
+	return "file:" + filePath
+}
+
+// reverseDependencies returns all packages that directly or transitively
+// import one of the given packages.
+func (w *Watcher) reverseDependencies(pkgPaths map[string]bool) map[string]bool {
+	importers := make(map[string][]string)
+	for pkgPath, imports := range w.imports {
+		for _, imp := range imports {
+			importers[imp] = append(importers[imp], pkgPath)
+		}
+	}
+
+	result := make(map[string]bool)
+	queue := make([]string, 0, len(pkgPaths))
+	for pkgPath := range pkgPaths {
+		queue = append(queue, pkgPath)
+	}
+	for len(queue) > 0 {
+		pkgPath := queue[0]
+		queue = queue[1:]
+		for _, importer := range importers[pkgPath] {
+			if !result[importer] && !pkgPaths[importer] {
+				result[importer] = true
+				queue = append(queue, importer)
+			}
+		}
+	}
+	return result
+}
+
+// scan records the state of every watched file under the project path.
+func (w *Watcher) scan() map[string]fileState {
+	files := make(map[string]fileState)
+	filepath.WalkDir(w.ProjectPath, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return nil // Files may disappear while scanning
+		}
+		name := d.Name()
+		if d.IsDir() {
+			if path != w.ProjectPath && (strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_") || name == "testdata") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !w.ParseOptions.NonGoFiles && !strings.HasSuffix(name, ".go") && !isModuleFile(name) {
+			return nil
+		}
+		if info, err := d.Info(); err == nil {
+			files[path] = fileState{modTime: info.ModTime(), size: info.Size()}
+		}
+		return nil
+	})
+	return files
+}
+
+// isModuleFile reports whether a file name is go.mod or go.work, whose
+// changes affect every package.
+func isModuleFile(name string) bool {
+	return name == "go.mod" || name == "go.work"
+}
+
+// enclosingPackage returns the package of dir or of its closest parent
+// directory within the project.
+func (w *Watcher) enclosingPackage(dir string) (string, bool) {
+	for {
+		if pkgPath, ok := w.dirs[dir]; ok {
+			return pkgPath, true
+		}
+		parent := filepath.Dir(dir)
+		if dir == w.ProjectPath || parent == dir {
+			return "", false
+		}
+		dir = parent
+	}
+}
+
+// changedFiles returns files that were added, modified or removed between two scans.
+func changedFiles(previous, current map[string]fileState) []string {
+	var changed []string
+	for path, state := range current {
+		if old, ok := previous[path]; !ok || old != state {
+			changed = append(changed, path)
+		}
+	}
+	for path := range previous {
+		if _, ok := current[path]; !ok {
+			changed = append(changed, path)
+		}
+	}
+	return changed
+}
+
+// sameChunk reports whether two chunks with the same ID have identical content.
+func sameChunk(a, b types.ChromaDocument) bool {
+	if a.Document != b.Document {
+		return false
+	}
+	aMeta, errA := json.Marshal(a.Metadata)
+	bMeta, errB := json.Marshal(b.Metadata)
+	return errA == nil && errB == nil && string(aMeta) == string(bMeta)
+}
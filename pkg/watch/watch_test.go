@@ -0,0 +1,100 @@
+package watch
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/sunku5494/go-ast-parser/pkg/loader"
+	"github.com/sunku5494/go-ast-parser/pkg/types"
+)
+
+// recordingSink collects the chunks upserted by a Watcher.
+type recordingSink struct {
+	mu      sync.Mutex
+	upserts []types.ChromaDocument
+}
+
+func (s *recordingSink) Upsert(chunks []types.ChromaDocument) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.upserts = append(s.upserts, chunks...)
+	return nil
+}
+
+func (s *recordingSink) Delete(ids []string) error {
+	return nil
+}
+
+// find returns the first upserted chunk accepted by match.
+func (s *recordingSink) find(match func(types.ChromaDocument) bool) (types.ChromaDocument, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, chunk := range s.upserts {
+		if match(chunk) {
+			return chunk, true
+		}
+	}
+	return types.ChromaDocument{}, false
+}
+
+func TestWatchPushesGoWorkChanges(t *testing.T) {
+	// The fixture is a workspace, in which -mod=mod is not allowed
+	t.Setenv("GOFLAGS", "")
+
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "go.mod"), "module example.com/w\n\ngo 1.23\n")
+	writeFile(t, filepath.Join(dir, "go.work"), "go 1.23\n\nuse .\n")
+	writeFile(t, filepath.Join(dir, "w.go"), "package w\n\nfunc W() int { return 1 }\n")
+
+	sink := &recordingSink{}
+	w := New(dir, sink)
+	w.Interval = 10 * time.Millisecond
+	w.Debounce = 50 * time.Millisecond
+	w.LoadOptions = loader.Options{SkipVendor: true}
+	w.ParseOptions.NonGoFiles = true
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- w.Run(ctx) }()
+	defer func() {
+		cancel()
+		if err := <-done; err != nil {
+			t.Errorf("Run: %v", err)
+		}
+	}()
+
+	isGoWork := func(marker string) func(types.ChromaDocument) bool {
+		return func(chunk types.ChromaDocument) bool {
+			return chunk.Metadata["entity_type"] == "go_work" && strings.Contains(chunk.Document, marker)
+		}
+	}
+	waitFor(t, sink, isGoWork("use ."))
+
+	writeFile(t, filepath.Join(dir, "go.work"), "go 1.23\n\n// edited\nuse .\n")
+	waitFor(t, sink, isGoWork("// edited"))
+}
+
+// waitFor waits until the sink received a chunk accepted by match.
+func waitFor(t *testing.T, sink *recordingSink, match func(types.ChromaDocument) bool) {
+	t.Helper()
+	deadline := time.Now().Add(30 * time.Second)
+	for time.Now().Before(deadline) {
+		if _, ok := sink.find(match); ok {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatal("timed out waiting for the chunk to be pushed")
+}
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,57 @@
+#!/bin/sh
+# Benchmarks chunk extraction with BenchmarkParsePackages.
+#
+# The benchmark generates a fixture tree of BENCH_PACKAGES packages of
+# BENCH_FILES files, each with BENCH_SPECS type, const and var specs and as
+# many methods, and is run BENCH_RUNS times.
+#
+# With BENCH_WRITE_BASELINE=1 the results are written to BENCH_BASELINE.
+# Otherwise, when BENCH_BASELINE exists, the script fails if the median time
+# per extraction is more than BENCH_MAX_REGRESSION percent slower than in the
+# baseline, and prints a benchstat comparison when benchstat is installed.
+# Baselines depend on the machine, so they are recorded locally and not
+# committed.
+#
+# BENCH_FLAGS are passed to `go test`, e.g. BENCH_FLAGS="-benchtime 5x".
+set -eu
+
+BENCH_PACKAGES=${BENCH_PACKAGES:-40}
+BENCH_FILES=${BENCH_FILES:-10}
+BENCH_SPECS=${BENCH_SPECS:-50}
+BENCH_RUNS=${BENCH_RUNS:-5}
+BENCH_BASELINE=${BENCH_BASELINE:-}
+BENCH_WRITE_BASELINE=${BENCH_WRITE_BASELINE:-0}
+BENCH_MAX_REGRESSION=${BENCH_MAX_REGRESSION:-20}
+BENCH_FLAGS=${BENCH_FLAGS:-}
+export BENCH_PACKAGES BENCH_FILES BENCH_SPECS
+
+# median prints the median ns/op of the benchmark results in a file.
+median() {
+	awk '$1 ~ /^BenchmarkParsePackages/ { print $3 }' "$1" | sort -n |
+		awk '{ v[NR] = $1 } END { if (NR % 2) print v[(NR + 1) / 2]; else print (v[NR / 2] + v[NR / 2 + 1]) / 2 }'
+}
+
+results=$(mktemp)
+trap 'rm -f "$results"' EXIT
+
+echo "Benchmarking $BENCH_PACKAGES packages x $BENCH_FILES files x $BENCH_SPECS specs, $BENCH_RUNS runs"
+status=0
+go test -run '^$' -bench '^BenchmarkParsePackages$' -benchmem -count "$BENCH_RUNS" $BENCH_FLAGS ./pkg/parser/ > "$results" || status=$?
+cat "$results"
+[ "$status" -eq 0 ] || exit "$status"
+extract=$(median "$results")
+
+if [ -n "$BENCH_BASELINE" ] && [ "$BENCH_WRITE_BASELINE" = 1 ]; then
+	cp "$results" "$BENCH_BASELINE"
+	echo "Wrote baseline to $BENCH_BASELINE"
+elif [ -n "$BENCH_BASELINE" ] && [ -f "$BENCH_BASELINE" ]; then
+	if command -v benchstat > /dev/null; then
+		benchstat "$BENCH_BASELINE" "$results"
+	fi
+	baseline=$(median "$BENCH_BASELINE")
+	if awk -v e="$extract" -v b="$baseline" -v m="$BENCH_MAX_REGRESSION" 'BEGIN { exit !(e > b * (1 + m / 100)) }'; then
+		echo "FAIL: extraction took ${extract} ns/op, more than ${BENCH_MAX_REGRESSION}% over the baseline of ${baseline} ns/op"
+		exit 1
+	fi
+	echo "OK: extraction took ${extract} ns/op (baseline ${baseline} ns/op)"
+fi